@@ -0,0 +1,79 @@
+// Command devcycle-tf-export generates the Terraform configuration of an
+// existing DevCycle project, along with the import blocks bringing it under
+// management.
+//
+//	DEVCYCLE_CLIENT_ID=... DEVCYCLE_CLIENT_SECRET=... go run ./cmd/devcycle-tf-export -project <key> -out <dir>
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/devcyclehq/terraform-provider-devcycle/internal/provider"
+)
+
+func main() {
+	project := flag.String("project", "", "key or ID of the project to export")
+	out := flag.String("out", ".", "directory the .tf files are written to")
+	var settings provider.ExportSettings
+	flag.StringVar(&settings.ProxyURL, "proxy-url", "", "URL of the proxy the requests are sent through, defaults to the HTTPS_PROXY environment variable")
+	flag.StringVar(&settings.CACertFile, "ca-cert-file", "", "PEM encoded CA certificates trusted along with the system ones")
+	clientCertFile := flag.String("client-cert-file", "", "PEM encoded client certificate for mutual TLS")
+	clientKeyFile := flag.String("client-key-file", "", "PEM encoded private key of -client-cert-file")
+	flag.BoolVar(&settings.InsecureSkipVerify, "insecure-skip-verify", false, "don't verify TLS certificates, only for testing")
+	flag.StringVar(&settings.RequestTimeout, "request-timeout", "", "timeout of each request, e.g. 30s")
+	flag.Parse()
+
+	if *project == "" {
+		fmt.Fprintln(os.Stderr, "devcycle-tf-export: -project is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	for _, pem := range []struct {
+		file  string
+		value *string
+	}{{*clientCertFile, &settings.ClientCert}, {*clientKeyFile, &settings.ClientKey}} {
+		if pem.file == "" {
+			continue
+		}
+		contents, err := os.ReadFile(pem.file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		*pem.value = string(contents)
+	}
+
+	// The credentials are read from the environment by ExportProject.
+	files, warnings, err := provider.ExportProject(context.Background(), settings, *project)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, "warning:", warning)
+	}
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(*out, name)
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(path)
+	}
+}
@@ -0,0 +1,3 @@
+data "devcycle_custom_properties" "test" {
+  project_key = "terraform-provider-testing"
+}
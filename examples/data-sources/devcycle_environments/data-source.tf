@@ -0,0 +1,4 @@
+data "devcycle_environments" "production" {
+  project_key = "terraform-provider-testing"
+  type        = "production"
+}
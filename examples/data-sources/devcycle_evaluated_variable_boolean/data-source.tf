@@ -1,7 +1,20 @@
 data "devcycle_evaluated_variable_boolean" "test" {
-  id = "acceptance-testing-boolean"
+  key = "acceptance-testing-boolean"
   user = {
     id = "acceptancetesting"
   }
   default_value = false
-}
\ No newline at end of file
+}
+
+# Evaluate in another environment than the one of the provider server_sdk_token
+data "devcycle_evaluated_variable_boolean" "staging" {
+  key = "acceptance-testing-boolean"
+  user = {
+    id = "acceptancetesting"
+  }
+  default_value = false
+  environment = {
+    project_id = "622112634cabe0e9fbaf974d"
+    key        = "staging"
+  }
+}
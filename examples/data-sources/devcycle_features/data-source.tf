@@ -0,0 +1,13 @@
+data "devcycle_features" "release" {
+  project_key   = "terraform-provider-testing"
+  type          = "release"
+  status        = "active"
+  tags          = ["checkout"]
+  updated_after = "2024-01-01T00:00:00Z"
+  sort_by       = "updated_at"
+  sort_order    = "desc"
+}
+
+output "release_feature_keys" {
+  value = data.devcycle_features.release.features[*].key
+}
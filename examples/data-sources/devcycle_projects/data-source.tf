@@ -0,0 +1,3 @@
+data "devcycle_projects" "all" {
+  search = "terraform"
+}
@@ -0,0 +1,9 @@
+data "devcycle_variables" "checkout" {
+  project_key = "terraform-provider-testing"
+  feature     = "checkout"
+  type        = "Boolean"
+}
+
+output "checkout_variables" {
+  value = { for variable in data.devcycle_variables.checkout.variables : variable.key => variable.name }
+}
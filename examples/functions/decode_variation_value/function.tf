@@ -0,0 +1,13 @@
+resource "devcycle_variable" "config" {
+  name          = "Checkout Config"
+  key           = "checkout-config"
+  description   = "Checkout configuration"
+  type          = "JSON"
+  feature_id    = "622115014b06357d06d1cf3e"
+  project_id    = "622112634cabe0e9fbaf974d"
+  default_value = jsonencode({ currency = "USD", limit = 10 })
+}
+
+output "checkout_currency" {
+  value = provider::devcycle::decode_variation_value("JSON", devcycle_variable.config.default_value).currency
+}
@@ -0,0 +1,13 @@
+resource "devcycle_variation" "limits" {
+  project_id = "622112634cabe0e9fbaf974d"
+  feature_id = "terraform-provider-feature"
+  key        = "variation-limits"
+  name       = "Limits"
+  variables = {
+    "checkout-retries" = provider::devcycle::encode_variation_value("Number", 3)
+    "checkout-config" = provider::devcycle::encode_variation_value("JSON", {
+      currency = "USD"
+      limit    = 10
+    })
+  }
+}
@@ -0,0 +1,7 @@
+resource "devcycle_feature" "checkout" {
+  project_id  = "622112634cabe0e9fbaf974d"
+  name        = "New Checkout Flow"
+  key         = provider::devcycle::key("New Checkout Flow") # "new-checkout-flow"
+  description = "New checkout flow"
+  type        = "release"
+}
@@ -0,0 +1 @@
+terraform import devcycle_custom_property.test 622112634cabe0e9fbaf974d/subscription-plan
@@ -0,0 +1,8 @@
+resource "devcycle_custom_property" "test" {
+  project_id   = "622112634cabe0e9fbaf974d"
+  name         = "Subscription Plan"
+  key          = "subscription-plan"
+  type         = "String"
+  property_key = "plan"
+  enum_values  = ["free", "pro", "enterprise"]
+}
@@ -0,0 +1 @@
+terraform import devcycle_environment.test 622112634cabe0e9fbaf974d/production
@@ -0,0 +1,22 @@
+resource "devcycle_feature_targeting" "test" {
+  project_id     = "622112634cabe0e9fbaf974d"
+  feature_id     = "terraform-provider-feature"
+  environment_id = "production"
+  status         = "active"
+
+  targets = [{
+    name = "All Users"
+    distribution = {
+      "variation-on" = 1
+    }
+    rollout = {
+      type             = "stepped"
+      start_date       = "2030-01-01T00:00:00Z"
+      start_percentage = 0.05
+      stages = [
+        { percentage = 0.25, date = "2030-01-03T00:00:00Z" },
+        { percentage = 1, date = "2030-01-07T00:00:00Z" },
+      ]
+    }
+  }]
+}
@@ -0,0 +1 @@
+terraform import devcycle_project.test terraform-provider-project
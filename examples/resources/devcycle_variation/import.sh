@@ -0,0 +1 @@
+terraform import devcycle_variation.test 622112634cabe0e9fbaf974d/terraform-provider-feature/variation-on
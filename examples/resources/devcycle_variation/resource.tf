@@ -0,0 +1,9 @@
+resource "devcycle_variation" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  feature_id = "terraform-provider-feature"
+  key        = "variation-on"
+  name       = "Variation On"
+  variables = {
+    "enable-new-checkout" = "true"
+  }
+}
@@ -1,9 +1,12 @@
 package dvc_oauth
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -11,26 +14,67 @@ type Auth0 struct {
 	AccessToken string `json:"access_token"`
 	ExpiresIn   int    `json:"expires_in"`
 	TokenType   string `json:"token_type"`
+
+	// Error and ErrorDescription are set when the token request is rejected,
+	// e.g. because of invalid client credentials.
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
 }
 
+// GetAuthToken exchanges client credentials for a management API access
+// token with http.DefaultClient.
 func GetAuthToken(clientId, clientSecret string) (Auth0, error) {
-	url := "https://auth.devcycle.com/oauth/token"
+	return GetAuthTokenWithClient(context.Background(), http.DefaultClient, clientId, clientSecret)
+}
 
-	payload := strings.NewReader("grant_type=client_credentials&client_id=" + clientId + "&client_secret=" + clientSecret + "&audience=https://api.devcycle.com/")
+// GetAuthTokenWithClient exchanges client credentials for a management API
+// access token with the given client, e.g. one sending requests through a
+// proxy, and context.
+func GetAuthTokenWithClient(ctx context.Context, client *http.Client, clientId, clientSecret string) (Auth0, error) {
+	tokenURL := "https://auth.devcycle.com/oauth/token"
 
-	req, _ := http.NewRequest("POST", url, payload)
+	payload := strings.NewReader(url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+		"audience":      {"https://api.devcycle.com/"},
+	}.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, payload)
+	if err != nil {
+		return Auth0{}, err
+	}
 
 	req.Header.Add("content-type", "application/x-www-form-urlencoded")
 
-	res, _ := http.DefaultClient.Do(req)
+	res, err := client.Do(req)
+	if err != nil {
+		return Auth0{}, fmt.Errorf("unable to reach %s: %w", tokenURL, err)
+	}
 
 	defer res.Body.Close()
-	body, _ := io.ReadAll(res.Body)
-	var auth Auth0
-	err := json.Unmarshal(body, &auth)
+	body, err := io.ReadAll(res.Body)
 	if err != nil {
+		return Auth0{}, err
+	}
+	var auth Auth0
+	if err := json.Unmarshal(body, &auth); err != nil && res.StatusCode == http.StatusOK {
 		return auth, err
 	}
 
+	if res.StatusCode != http.StatusOK {
+		reason := auth.ErrorDescription
+		if reason == "" {
+			reason = auth.Error
+		}
+		if reason == "" {
+			reason = http.StatusText(res.StatusCode)
+		}
+		return auth, fmt.Errorf("token request was rejected with HTTP %d: %s", res.StatusCode, reason)
+	}
+	if auth.AccessToken == "" {
+		return auth, fmt.Errorf("token response doesn't contain an access token")
+	}
+
 	return auth, nil
 }
@@ -9,7 +9,8 @@ import (
 func main() {
 	token, err := dvc_oauth.GetAuthToken(os.Getenv("DEVCYCLE_CLIENT_ID"), os.Getenv("DEVCYCLE_CLIENT_SECRET"))
 	if err != nil {
-		return
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 	fmt.Println(token.AccessToken)
 }
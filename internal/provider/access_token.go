@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// readAccessTokenFile reads a management API access token from a file. The
+// token is trimmed of surrounding whitespace, e.g. a trailing newline.
+func readAccessTokenFile(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(contents))
+	if token == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return token, nil
+}
+
+// accessTokenFileTransport authenticates management API requests with the
+// token of access_token_file. The file is read again for every request so
+// that short-lived tokens rotated while Terraform runs, e.g. by a CI job
+// minting tokens with an OIDC exchange, are picked up on refresh.
+type accessTokenFileTransport struct {
+	base http.RoundTripper
+	path string
+}
+
+func (t accessTokenFileTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := readAccessTokenFile(t.path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read access_token_file: %w", err)
+	}
+	cloned := req.Clone(req.Context())
+	cloned.Header.Set("Authorization", token)
+	return t.base.RoundTrip(cloned)
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAccessTokenFileTransport(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	client := &http.Client{Transport: accessTokenFileTransport{base: http.DefaultTransport, path: tokenFile}}
+	get := func() error {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "stale")
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(); err == nil {
+		t.Error("expected a missing token file to fail the request")
+	}
+
+	for _, token := range []string{"token-1", "token-2"} {
+		if err := os.WriteFile(tokenFile, []byte(token+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := get(); err != nil {
+			t.Fatal(err)
+		}
+		if authorization != token {
+			t.Errorf("expected the request to be authenticated with %q, got %q", token, authorization)
+		}
+	}
+}
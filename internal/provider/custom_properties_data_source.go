@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &customPropertiesDataSource{}
+
+func newCustomPropertiesDataSource() datasource.DataSource {
+	return &customPropertiesDataSource{}
+}
+
+func (d *customPropertiesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_properties"
+}
+
+func (d *customPropertiesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Custom Properties data source. Lists all custom properties defined in a project.",
+
+		Attributes: map[string]schema.Attribute{
+			"project_key": schema.StringAttribute{
+				MarkdownDescription: "Project key",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Project key",
+				Computed:            true,
+			},
+			"custom_properties": schema.ListNestedAttribute{
+				MarkdownDescription: "Custom properties in the project, sorted by key",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Custom property ID",
+						},
+						"key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Custom property key",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Custom property display name",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Custom property datatype",
+						},
+						"property_key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Key of the property in the user's custom data",
+						},
+						"enum_values": schema.ListAttribute{
+							Computed:            true,
+							MarkdownDescription: "Allowed values for the custom property",
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type customPropertiesDataSourceData struct {
+	Id               types.String                             `tfsdk:"id"`
+	ProjectKey       types.String                             `tfsdk:"project_key"`
+	CustomProperties []customPropertiesDataSourceDataProperty `tfsdk:"custom_properties"`
+}
+
+type customPropertiesDataSourceDataProperty struct {
+	Id          types.String `tfsdk:"id"`
+	Key         types.String `tfsdk:"key"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	PropertyKey types.String `tfsdk:"property_key"`
+	EnumValues  []string     `tfsdk:"enum_values"`
+}
+
+type customPropertiesDataSource struct {
+	dataSourceBase
+}
+
+func (d *customPropertiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data customPropertiesDataSourceData
+	if !d.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	properties, httpResponse, err := listMgmtPages[customProperty](ctx, d.provider, customPropertyPath(data.ProjectKey.ValueString(), ""), nil)
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+
+	sort.Slice(properties, func(i, j int) bool {
+		return properties[i].Key < properties[j].Key
+	})
+
+	data.Id = data.ProjectKey
+	data.CustomProperties = []customPropertiesDataSourceDataProperty{}
+	for _, property := range properties {
+		data.CustomProperties = append(data.CustomProperties, customPropertiesDataSourceDataProperty{
+			Id:          types.StringValue(property.Id),
+			Key:         types.StringValue(property.Key),
+			Name:        types.StringValue(property.Name),
+			Type:        types.StringValue(property.Type),
+			PropertyKey: types.StringValue(property.PropertyKey),
+			EnumValues:  property.enumValues(nil),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
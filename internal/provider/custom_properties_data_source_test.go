@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCustomPropertiesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccCustomPropertiesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devcycle_custom_properties.test", "id", "terraform-provider-testing"),
+					resource.TestCheckResourceAttrSet("data.devcycle_custom_properties.test", "custom_properties.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCustomPropertiesDataSourceConfig = `
+data "devcycle_custom_properties" "test" {
+  project_key = "terraform-provider-testing"
+}
+`
@@ -0,0 +1,350 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.ResourceWithConfigure = &customPropertyResource{}
+var _ resource.ResourceWithImportState = &customPropertyResource{}
+var _ resource.ResourceWithModifyPlan = &customPropertyResource{}
+
+func newCustomPropertyResource() resource.Resource {
+	return &customPropertyResource{}
+}
+
+func (r *customPropertyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_property"
+}
+
+func (r *customPropertyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Custom Property resource. Custom properties define the custom data schema that can be used when targeting users in a project.",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project id or key of the project to which the custom property belongs",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Custom property key, used by the management API to reference the custom property",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Custom property display name",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Custom property datatype. One of `String`, `Boolean` or `Number`",
+				Required:            true,
+				Validators: []validator.String{
+					stringOneOf("String", "Boolean", "Number"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"property_key": schema.StringAttribute{
+				MarkdownDescription: "Key of the property in the user's custom data, as sent by the SDKs",
+				Required:            true,
+			},
+			"enum_values": schema.ListAttribute{
+				MarkdownDescription: "Allowed values for the custom property. Values are converted to the custom property type.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"etag": etagAttribute(),
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Custom property ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+type customPropertyResourceData struct {
+	Id          types.String `tfsdk:"id"`
+	ProjectId   types.String `tfsdk:"project_id"`
+	Key         types.String `tfsdk:"key"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	PropertyKey types.String `tfsdk:"property_key"`
+	EnumValues  []string     `tfsdk:"enum_values"`
+	Etag        types.String `tfsdk:"etag"`
+}
+
+// customProperty mirrors the management API custom property model. The
+// generated go-mgmt-sdk client predates enum schemas and has no find one,
+// update or delete operations, so custom properties are managed with
+// doMgmtJSONRequest instead.
+type customProperty struct {
+	Id          string                `json:"_id,omitempty"`
+	Project     string                `json:"_project,omitempty"`
+	Key         string                `json:"key,omitempty"`
+	Name        string                `json:"name,omitempty"`
+	Type        string                `json:"type,omitempty"`
+	PropertyKey string                `json:"propertyKey,omitempty"`
+	Schema      *customPropertySchema `json:"schema,omitempty"`
+}
+
+type customPropertyUpdate struct {
+	Name        string                `json:"name,omitempty"`
+	PropertyKey string                `json:"propertyKey,omitempty"`
+	Schema      *customPropertySchema `json:"schema"`
+}
+
+type customPropertySchema struct {
+	SchemaType string                    `json:"schemaType"`
+	Required   bool                      `json:"required"`
+	EnumSchema *customPropertyEnumSchema `json:"enumSchema,omitempty"`
+}
+
+type customPropertyEnumSchema struct {
+	AllowedValues         []customPropertyEnumValue `json:"allowedValues"`
+	AllowAdditionalValues bool                      `json:"allowAdditionalValues"`
+}
+
+type customPropertyEnumValue struct {
+	Label string      `json:"label"`
+	Value interface{} `json:"value"`
+}
+
+func (d customPropertyResourceData) toSDK(diags *diag.Diagnostics) customProperty {
+	ret := customProperty{
+		Key:         d.Key.ValueString(),
+		Name:        d.Name.ValueString(),
+		Type:        d.Type.ValueString(),
+		PropertyKey: d.PropertyKey.ValueString(),
+	}
+	if d.EnumValues == nil {
+		return ret
+	}
+
+	enumSchema := &customPropertyEnumSchema{}
+	for _, value := range d.EnumValues {
+		parsed, err := parseTypedValue(d.Type.ValueString(), value)
+		if err != nil {
+			diags.AddError("Invalid Enum Value", fmt.Sprintf("Unable to convert enum value %q to %s: %s", value, d.Type.ValueString(), err))
+			continue
+		}
+		enumSchema.AllowedValues = append(enumSchema.AllowedValues, customPropertyEnumValue{
+			Label: value,
+			Value: parsed,
+		})
+	}
+	ret.Schema = &customPropertySchema{
+		SchemaType: "enum",
+		EnumSchema: enumSchema,
+	}
+	return ret
+}
+
+func (d *customPropertyResourceData) fromSDK(property customProperty) {
+	d.Id = types.StringValue(property.Id)
+	d.ProjectId = types.StringValue(property.Project)
+	d.Key = types.StringValue(property.Key)
+	d.Name = types.StringValue(property.Name)
+	d.Type = types.StringValue(property.Type)
+	d.PropertyKey = types.StringValue(property.PropertyKey)
+	d.EnumValues = property.enumValues(d.EnumValues)
+}
+
+// enumValues returns the allowed values of the custom property in their
+// Terraform representation. The value at the same index of prior is kept when
+// it is equivalent, e.g. "1.0" for the Number 1.
+func (c customProperty) enumValues(prior []string) []string {
+	if c.Schema == nil || c.Schema.EnumSchema == nil {
+		return nil
+	}
+	var ret []string
+	for i, value := range c.Schema.EnumSchema.AllowedValues {
+		priorValue := types.StringNull()
+		if i < len(prior) {
+			priorValue = types.StringValue(prior[i])
+		}
+		formatted := typedValueToTF(c.Type, value.Value, priorValue)
+		if formatted.IsNull() {
+			formatted = types.StringValue(value.Label)
+		}
+		ret = append(ret, formatted.ValueString())
+	}
+	return ret
+}
+
+func customPropertyPath(project, key string) string {
+	path := fmt.Sprintf("/v1/projects/%s/customProperties", url.PathEscape(project))
+	if key != "" {
+		path += "/" + url.PathEscape(key)
+	}
+	return path
+}
+
+type customPropertyResource struct {
+	resourceBase
+}
+
+func (r *customPropertyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.warnRemoteKeyCollision(ctx, req, resp, customPropertyKeyCheck)
+}
+
+func (r *customPropertyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data customPropertyResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := data.toSDK(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var property customProperty
+	httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodPost, customPropertyPath(data.ProjectId.ValueString(), ""), nil, body, &property)
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+
+	projectId := data.ProjectId
+	data.fromSDK(property)
+	data.Etag = etagToTF(httpResponse)
+	// Keep the project as configured, it may be a key rather than the ID.
+	data.ProjectId = projectId
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *customPropertyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data customPropertyResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var property customProperty
+	httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodGet, customPropertyPath(data.ProjectId.ValueString(), data.Key.ValueString()), nil, nil, &property)
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+
+	projectId := data.ProjectId
+	data.fromSDK(property)
+	data.Etag = etagToTF(httpResponse)
+	data.ProjectId = projectId
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *customPropertyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data customPropertyResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := data.toSDK(&resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The type of a custom property can't be changed and changing the key
+	// requires replacement, so only the remaining fields are patched. The
+	// schema is always sent so that removing enum_values clears it.
+	update := customPropertyUpdate{
+		Name:        body.Name,
+		PropertyKey: body.PropertyKey,
+		Schema:      body.Schema,
+	}
+
+	var etag types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("etag"), &etag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var property customProperty
+	httpResponse, err := r.provider.doMgmtJSONRequest(withIfMatch(ctx, etag), http.MethodPatch, customPropertyPath(data.ProjectId.ValueString(), data.Key.ValueString()), nil, update, &property)
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+
+	projectId := data.ProjectId
+	data.fromSDK(property)
+	data.Etag = etagToTF(httpResponse)
+	data.ProjectId = projectId
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *customPropertyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data customPropertyResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResponse, err := r.provider.doMgmtJSONRequest(withIfMatch(ctx, data.Etag), http.MethodDelete, customPropertyPath(data.ProjectId.ValueString(), data.Key.ValueString()), nil, nil, nil)
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *customPropertyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importProjectScopedKey(ctx, "project_id", req, resp)
+}
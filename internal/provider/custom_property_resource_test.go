@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestCustomPropertyEnumValues(t *testing.T) {
+	data := customPropertyResourceData{Type: types.StringValue("Number"), EnumValues: []string{"1.0", "2.50", "3"}}
+	var diags diag.Diagnostics
+	body := data.toSDK(&diags)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	// The values are returned as JSON numbers.
+	var property customProperty
+	marshalled, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(marshalled, &property); err != nil {
+		t.Fatal(err)
+	}
+	property.Schema.EnumSchema.AllowedValues[2].Value = 4.0
+
+	data.fromSDK(property)
+	if expected := []string{"1.0", "2.50", "4"}; !reflect.DeepEqual(data.EnumValues, expected) {
+		t.Errorf("expected the equivalent configured values to be kept, got %v", data.EnumValues)
+	}
+	if values := property.enumValues(nil); !reflect.DeepEqual(values, []string{"1", "2.5", "4"}) {
+		t.Errorf("expected the values to be normalized without prior values, got %v", values)
+	}
+}
+
+func TestAccCustomPropertyResource(t *testing.T) {
+	testAccPreCheck(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccCustomPropertyResourceConfig("String"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_custom_property.test", "key", "terraform-acceptance-testing"+randString),
+					resource.TestCheckResourceAttr("devcycle_custom_property.test", "enum_values.#", "2"),
+				),
+			},
+			{
+				Config:  testAccCustomPropertyResourceConfig("String"),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func testAccCustomPropertyResourceConfig(propertyType string) string {
+	return `
+resource "devcycle_custom_property" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  name = "TerraformAccTest` + randString + `"
+  key = "terraform-acceptance-testing` + randString + `"
+  type = "` + propertyType + `"
+  property_key = "terraform-acceptance-testing` + randString + `"
+  enum_values = ["alpha", "beta"]
+}
+`
+}
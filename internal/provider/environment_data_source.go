@@ -2,77 +2,67 @@ package provider
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type environmentDataSourceType struct{}
+var _ datasource.DataSourceWithConfigure = &environmentDataSource{}
+
+func newEnvironmentDataSource() datasource.DataSource {
+	return &environmentDataSource{}
+}
+
+func (d *environmentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment"
+}
 
-func (t environmentDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
+func (d *environmentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: `DevCycle Environment Data Source. Read data from a given DevCycle Environment. R`,
 
-		Attributes: map[string]tfsdk.Attribute{
-			"project_id": {
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
 				MarkdownDescription: `Project id of the project to which the environment belongs.`,
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"project_key": {
+			"project_key": schema.StringAttribute{
 				MarkdownDescription: "Project key or id of the project to which the environment belongs",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"name": {
+			"name": schema.StringAttribute{
 				MarkdownDescription: "Environment Name",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"key": {
+			"key": schema.StringAttribute{
 				MarkdownDescription: "Environment Key (Human readable id)",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"description": {
+			"description": schema.StringAttribute{
 				MarkdownDescription: "Environment Description",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"color": {
+			"color": schema.StringAttribute{
 				MarkdownDescription: "Environment Color in Hex with leading #",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"type": {
+			"type": schema.StringAttribute{
 				MarkdownDescription: "Environment Type",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"id": {
+			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Environment Key",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
-				},
-				Type: types.StringType,
 			},
-			"sdk_keys": {
+			"sdk_keys": schema.ListAttribute{
 				Computed:            true,
 				MarkdownDescription: "SDK Keys for the environment",
-				Type:                types.ListType{ElemType: types.StringType},
+				ElementType:         types.StringType,
 			},
 		},
-	}, nil
-}
-
-func (t environmentDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return environmentDataSource{
-		provider: provider,
-	}, diags
+	}
 }
 
 type environmentDataSourceData struct {
@@ -88,16 +78,12 @@ type environmentDataSourceData struct {
 }
 
 type environmentDataSource struct {
-	provider provider
+	dataSourceBase
 }
 
-func (d environmentDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data environmentDataSourceData
-	if !d.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !d.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.Config.Get(ctx, &data)
@@ -106,18 +92,18 @@ func (d environmentDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	environment, httpResponse, err := d.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerFindOne(ctx, data.Key.Value, data.ProjectKey.Value)
+	environment, httpResponse, err := d.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerFindOne(ctx, data.Key.ValueString(), data.ProjectKey.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Id = types.String{Value: environment.Id}
-	data.Key = types.String{Value: environment.Key}
-	data.Name = types.String{Value: environment.Name}
-	data.Description = types.String{Value: environment.Description}
-	data.Color = types.String{Value: environment.Color}
-	data.Type = types.String{Value: environment.Type_}
-	data.ProjectId = types.String{Value: environment.Project}
+	data.Id = types.StringValue(environment.Id)
+	data.Key = types.StringValue(environment.Key)
+	data.Name = types.StringValue(environment.Name)
+	data.Description = types.StringValue(environment.Description)
+	data.Color = types.StringValue(environment.Color)
+	data.Type = types.StringValue(environment.Type_)
+	data.ProjectId = types.StringValue(environment.Project)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Mobile)...)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Server)...)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Client)...)
@@ -2,87 +2,94 @@ package provider
 
 import (
 	"context"
-	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-type environmentResourceType struct{}
+var _ resource.ResourceWithConfigure = &environmentResource{}
+var _ resource.ResourceWithImportState = &environmentResource{}
+var _ resource.ResourceWithUpgradeState = &environmentResource{}
+var _ resource.ResourceWithModifyPlan = &environmentResource{}
+
+func newEnvironmentResource() resource.Resource {
+	return &environmentResource{}
+}
+
+func (r *environmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment"
+}
+
+func (r *environmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// Version 1 added etag.
+		Version: 1,
 
-func (t environmentResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "DevCycle Environment resource. This resource is used to create and manage DevCycle environments.",
 
-		Attributes: map[string]tfsdk.Attribute{
-			"project_id": {
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
 				MarkdownDescription: "Project id or key of the project to which the environment belongs. Using the key (human readable name) is recommended when not managing the project through Terraform.",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"name": {
+			"name": schema.StringAttribute{
 				MarkdownDescription: "Environment Name",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"key": {
+			"key": schema.StringAttribute{
 				MarkdownDescription: "Environment Key",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"description": {
+			"description": schema.StringAttribute{
 				MarkdownDescription: "Environment Description",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"color": {
+			"color": schema.StringAttribute{
 				MarkdownDescription: "Environment Color in Hex with leading #",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"type": {
+			"type": schema.StringAttribute{
 				MarkdownDescription: "Environment Type",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"settings": {
+			"settings": schema.SingleNestedAttribute{
 				MarkdownDescription: "Environment Settings",
 				Required:            true,
-				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
-					"app_icon_uri": {
+				Attributes: map[string]schema.Attribute{
+					"app_icon_uri": schema.StringAttribute{
 						MarkdownDescription: "Environment App Icon Uri",
 						Required:            true,
-						Type:                types.StringType,
 					},
-				}),
+				},
 			},
-			"id": {
+			"etag": etagAttribute(),
+			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Environment Key",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
-				Type: types.StringType,
 			},
-			"sdk_keys": {
+			"sdk_keys": schema.ListAttribute{
 				Computed:            true,
 				MarkdownDescription: "SDK Keys for the environment",
-				Type:                types.ListType{ElemType: types.StringType},
+				ElementType:         types.StringType,
 			},
 		},
-	}, nil
+	}
 }
 
-func (t environmentResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return environmentResource{
-		provider: provider,
-	}, diags
+func (r *environmentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(
+		withDefaults(map[string]interface{}{"etag": nil}),
+	)
 }
 
 type environmentResourceData struct {
@@ -95,6 +102,7 @@ type environmentResourceData struct {
 	Settings    environmentResourceDataSettings `tfsdk:"settings"`
 	ProjectId   types.String                    `tfsdk:"project_id"`
 	SDKKeys     []string                        `tfsdk:"sdk_keys"`
+	Etag        types.String                    `tfsdk:"etag"`
 }
 
 func sdkKeyConvert(keys []devcyclem.ApiKey) []string {
@@ -111,26 +119,26 @@ type environmentResourceDataSettings struct {
 
 func (s *environmentResourceDataSettings) toCreateSDK() *devcyclem.AllOfCreateEnvironmentDtoSettings {
 	return &devcyclem.AllOfCreateEnvironmentDtoSettings{
-		AppIconURI: s.AppIconURI.Value,
+		AppIconURI: s.AppIconURI.ValueString(),
 	}
 }
 func (s *environmentResourceDataSettings) toUpdateSDK() *devcyclem.AllOfUpdateEnvironmentDtoSettings {
 	return &devcyclem.AllOfUpdateEnvironmentDtoSettings{
-		AppIconURI: s.AppIconURI.Value,
+		AppIconURI: s.AppIconURI.ValueString(),
 	}
 }
 
 type environmentResource struct {
-	provider provider
+	resourceBase
+}
+
+func (r *environmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.warnRemoteKeyCollision(ctx, req, resp, environmentKeyCheck)
 }
 
-func (r environmentResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+func (r *environmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data environmentResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.Config.Get(ctx, &data)
@@ -141,30 +149,31 @@ func (r environmentResource) Create(ctx context.Context, req tfsdk.CreateResourc
 	}
 
 	environment, httpResponse, err := r.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerCreate(ctx, devcyclem.CreateEnvironmentDto{
-		Name:        data.Name.Value,
-		Key:         data.Key.Value,
-		Description: data.Description.Value,
-		Color:       data.Color.Value,
-		Type_:       data.Type.Value,
+		Name:        data.Name.ValueString(),
+		Key:         data.Key.ValueString(),
+		Description: data.Description.ValueString(),
+		Color:       data.Color.ValueString(),
+		Type_:       data.Type.ValueString(),
 		Settings:    data.Settings.toCreateSDK(),
-	}, data.ProjectId.Value)
+	}, data.ProjectId.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Id = types.String{Value: environment.Id}
-	data.Key = types.String{Value: environment.Key}
-	data.Name = types.String{Value: environment.Name}
-	data.Description = types.String{Value: environment.Description}
-	data.Color = types.String{Value: environment.Color}
-	data.Type = types.String{Value: environment.Type_}
+	data.Id = types.StringValue(environment.Id)
+	data.Key = types.StringValue(environment.Key)
+	data.Name = types.StringValue(environment.Name)
+	data.Description = types.StringValue(environment.Description)
+	data.Color = types.StringValue(environment.Color)
+	data.Type = types.StringValue(environment.Type_)
 	data.Settings = environmentResourceDataSettings{
-		AppIconURI: types.String{Value: environment.Settings.AppIconURI},
+		AppIconURI: types.StringValue(environment.Settings.AppIconURI),
 	}
-	data.ProjectId = types.String{Value: environment.Project}
+	data.ProjectId = types.StringValue(environment.Project)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Mobile)...)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Server)...)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Client)...)
+	data.Etag = etagToTF(httpResponse)
 
 	// write logs using the tflog package
 	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
@@ -175,13 +184,9 @@ func (r environmentResource) Create(ctx context.Context, req tfsdk.CreateResourc
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r environmentResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data environmentResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.State.Get(ctx, &data)
@@ -191,35 +196,32 @@ func (r environmentResource) Read(ctx context.Context, req tfsdk.ReadResourceReq
 		return
 	}
 
-	environment, httpResponse, err := r.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerFindOne(ctx, data.Key.Value, data.ProjectId.Value)
+	environment, httpResponse, err := r.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerFindOne(ctx, data.Key.ValueString(), data.ProjectId.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
-	data.Id = types.String{Value: environment.Id}
-	data.Key = types.String{Value: environment.Key}
-	data.Name = types.String{Value: environment.Name}
-	data.Description = types.String{Value: environment.Description}
-	data.Color = types.String{Value: environment.Color}
-	data.Type = types.String{Value: environment.Type_}
+	data.Id = types.StringValue(environment.Id)
+	data.Key = types.StringValue(environment.Key)
+	data.Name = types.StringValue(environment.Name)
+	data.Description = types.StringValue(environment.Description)
+	data.Color = types.StringValue(environment.Color)
+	data.Type = types.StringValue(environment.Type_)
 	data.Settings = environmentResourceDataSettings{
-		AppIconURI: types.String{Value: environment.Settings.AppIconURI},
+		AppIconURI: types.StringValue(environment.Settings.AppIconURI),
 	}
-	data.ProjectId = types.String{Value: environment.Project}
+	data.ProjectId = types.StringValue(environment.Project)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Mobile)...)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Server)...)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Client)...)
+	data.Etag = etagToTF(httpResponse)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r environmentResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+func (r *environmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data environmentResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.Plan.Get(ctx, &data)
@@ -229,31 +231,38 @@ func (r environmentResource) Update(ctx context.Context, req tfsdk.UpdateResourc
 		return
 	}
 
-	environment, httpResponse, err := r.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerUpdate(ctx, devcyclem.UpdateEnvironmentDto{
-		Name:        data.Name.Value,
-		Key:         data.Key.Value,
-		Description: data.Description.Value,
-		Color:       data.Color.Value,
-		Type_:       data.Type.Value,
+	var etag types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("etag"), &etag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, httpResponse, err := r.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerUpdate(withIfMatch(ctx, etag), devcyclem.UpdateEnvironmentDto{
+		Name:        data.Name.ValueString(),
+		Key:         data.Key.ValueString(),
+		Description: data.Description.ValueString(),
+		Color:       data.Color.ValueString(),
+		Type_:       data.Type.ValueString(),
 		Settings:    data.Settings.toUpdateSDK(),
-	}, data.Key.Value, data.ProjectId.Value)
+	}, data.Key.ValueString(), data.ProjectId.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Id = types.String{Value: environment.Id}
-	data.Key = types.String{Value: environment.Key}
-	data.Name = types.String{Value: environment.Name}
-	data.Description = types.String{Value: environment.Description}
-	data.Color = types.String{Value: environment.Color}
-	data.Type = types.String{Value: environment.Type_}
+	data.Id = types.StringValue(environment.Id)
+	data.Key = types.StringValue(environment.Key)
+	data.Name = types.StringValue(environment.Name)
+	data.Description = types.StringValue(environment.Description)
+	data.Color = types.StringValue(environment.Color)
+	data.Type = types.StringValue(environment.Type_)
 	data.Settings = environmentResourceDataSettings{
-		AppIconURI: types.String{Value: environment.Settings.AppIconURI},
+		AppIconURI: types.StringValue(environment.Settings.AppIconURI),
 	}
-	data.ProjectId = types.String{Value: environment.Project}
+	data.ProjectId = types.StringValue(environment.Project)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Mobile)...)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Server)...)
 	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Client)...)
+	data.Etag = etagToTF(httpResponse)
 
 	// write logs using the tflog package
 	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
@@ -264,13 +273,9 @@ func (r environmentResource) Update(ctx context.Context, req tfsdk.UpdateResourc
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r environmentResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data environmentResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.State.Get(ctx, &data)
@@ -280,13 +285,13 @@ func (r environmentResource) Delete(ctx context.Context, req tfsdk.DeleteResourc
 		return
 	}
 
-	httpResponse, err := r.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerRemove(ctx, data.Key.Value, data.ProjectId.Value)
+	httpResponse, err := r.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerRemove(withIfMatch(ctx, data.Etag), data.Key.ValueString(), data.ProjectId.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 	resp.State.RemoveResource(ctx)
 }
 
-func (r environmentResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+func (r *environmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importProjectScopedKey(ctx, "project_id", req, resp)
 }
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &environmentsDataSource{}
+
+func newEnvironmentsDataSource() datasource.DataSource {
+	return &environmentsDataSource{}
+}
+
+func (d *environmentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environments"
+}
+
+func (d *environmentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Environments data source. Lists the environments of a project, optionally filtered.",
+
+		Attributes: listFilterAttributes(map[string]schema.Attribute{
+			"project_key": schema.StringAttribute{
+				MarkdownDescription: "Project key",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only return environments of this type, e.g. `development` or `production`",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Project key",
+				Computed:            true,
+			},
+			"environments": schema.ListNestedAttribute{
+				MarkdownDescription: "Environments matching the filters, sorted by key unless `sort_by` is set",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Environment ID",
+						},
+						"key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Environment key",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Environment name",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Environment description",
+						},
+						"color": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Environment color in hex with leading #",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Environment type",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Created at timestamp",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Updated at timestamp",
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+type environmentsDataSourceData struct {
+	listFilterData
+	Id           types.String                            `tfsdk:"id"`
+	ProjectKey   types.String                            `tfsdk:"project_key"`
+	Type         types.String                            `tfsdk:"type"`
+	Environments []environmentsDataSourceDataEnvironment `tfsdk:"environments"`
+}
+
+type environmentsDataSourceDataEnvironment struct {
+	Id          types.String `tfsdk:"id"`
+	Key         types.String `tfsdk:"key"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Color       types.String `tfsdk:"color"`
+	Type        types.String `tfsdk:"type"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+}
+
+type environmentsDataSource struct {
+	dataSourceBase
+}
+
+func (d *environmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data environmentsDataSourceData
+	if !d.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environments, httpResponse, err := listMgmtPages[devcyclem.Environment](ctx, d.provider, fmt.Sprintf("/v1/projects/%s/environments", url.PathEscape(data.ProjectKey.ValueString())), data.query())
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+
+	var matching []devcyclem.Environment
+	for _, environment := range environments {
+		if data.Type.ValueString() == "" || environment.Type_ == data.Type.ValueString() {
+			matching = append(matching, environment)
+		}
+	}
+	matching = filterAndSortList(matching, data.listFilterData, func(environment devcyclem.Environment) listItemFields {
+		return listItemFields{Key: environment.Key, Name: environment.Name, CreatedAt: environment.CreatedAt, UpdatedAt: environment.UpdatedAt}
+	}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.ProjectKey
+	data.Environments = []environmentsDataSourceDataEnvironment{}
+	for _, environment := range matching {
+		data.Environments = append(data.Environments, environmentsDataSourceDataEnvironment{
+			Id:          types.StringValue(environment.Id),
+			Key:         types.StringValue(environment.Key),
+			Name:        types.StringValue(environment.Name),
+			Description: types.StringValue(environment.Description),
+			Color:       types.StringValue(environment.Color),
+			Type:        types.StringValue(environment.Type_),
+			CreatedAt:   listTimeToTF(environment.CreatedAt),
+			UpdatedAt:   listTimeToTF(environment.UpdatedAt),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
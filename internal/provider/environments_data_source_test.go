@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEnvironmentsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccEnvironmentsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devcycle_environments.test", "id", "terraform-provider-testing"),
+					resource.TestCheckResourceAttrSet("data.devcycle_environments.test", "environments.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccEnvironmentsDataSourceConfig = `
+data "devcycle_environments" "test" {
+  project_key = "terraform-provider-testing"
+}
+`
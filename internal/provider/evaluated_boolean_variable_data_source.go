@@ -3,56 +3,52 @@ package provider
 import (
 	"context"
 	"fmt"
+
 	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type evaluatedBoolVariableDataSourceType struct{}
+var _ datasource.DataSourceWithConfigure = &evaluatedBooleanVariableDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &evaluatedBooleanVariableDataSource{}
+
+func newEvaluatedBooleanVariableDataSource() datasource.DataSource {
+	return &evaluatedBooleanVariableDataSource{}
+}
 
-func (t evaluatedBoolVariableDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
+func (d *evaluatedBooleanVariableDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_evaluated_variable_boolean"
+}
+
+func (d *evaluatedBooleanVariableDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Evaluated Variable data source. Each instance of this data source represents a single evaluated variable, under a single userdata context.",
 
-		Attributes: map[string]tfsdk.Attribute{
+		Attributes: evaluationSourceAttributes(map[string]schema.Attribute{
 			"user": userDataSchema(),
-			"value": {
+			"value": schema.BoolAttribute{
 				MarkdownDescription: "Value of the Variable. Either true or false.",
 				Computed:            true,
-				Type:                types.BoolType,
 			},
-			"default_value": {
+			"default_value": schema.BoolAttribute{
 				MarkdownDescription: "Default value of the Variable. Used as a fallback in case there is no variation value set.",
 				Required:            true,
-				Type:                types.BoolType,
 			},
-			"key": {
+			"key": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Variable ID or key. Recommended to use the key when not managing an entire project in Terraform.",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
-				},
-				Type: types.StringType,
 			},
-			"id": {
+			"id": schema.StringAttribute{
 				Computed: true,
-				Type:     types.StringType,
 			},
-		},
-	}, nil
-}
-
-func (t evaluatedBoolVariableDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return evaluatedBooleanVariableDataSource{
-		provider: provider,
-	}, diags
+		}),
+	}
 }
 
 type evaluatedBooleanVariableDataSourceData struct {
+	evaluationSourceData
 	Key          types.String                        `tfsdk:"key"`
 	Value        types.Bool                          `tfsdk:"value"`
 	User         evaluatedVariableDataSourceDataUser `tfsdk:"user"`
@@ -61,38 +57,35 @@ type evaluatedBooleanVariableDataSourceData struct {
 }
 
 type evaluatedBooleanVariableDataSource struct {
-	provider provider
+	evaluatedDataSourceBase
 }
 
-func (d evaluatedBooleanVariableDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+func (d *evaluatedBooleanVariableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data evaluatedBooleanVariableDataSourceData
-	if !d.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
-		return
-	}
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := d.serverClient(ctx, data.evaluationSourceData, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	userData := dvc_server.DVCUser{
-		UserId: "" + data.User.Id.Value,
+		UserId: "" + data.User.Id.ValueString(),
 	}
 
-	variable, err := d.provider.ServerClient.Variable(userData, data.Key.Value, data.DefaultValue.Value)
+	variable, err := client.Variable(userData, data.Key.ValueString(), data.DefaultValue.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Variable, got error: %s", err))
 		return
 	}
 
-	data.Key = types.String{Value: variable.Key}
+	data.Key = types.StringValue(variable.Key)
 	data.Id = data.Key
-	data.Value = types.Bool{Value: variable.Value.(bool)}
+	data.Value = types.BoolValue(variable.Value.(bool))
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
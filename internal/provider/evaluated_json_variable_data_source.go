@@ -4,56 +4,52 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+
 	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type evaluatedJSONVariableDataSourceType struct{}
+var _ datasource.DataSourceWithConfigure = &evaluatedJSONVariableDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &evaluatedJSONVariableDataSource{}
+
+func newEvaluatedJSONVariableDataSource() datasource.DataSource {
+	return &evaluatedJSONVariableDataSource{}
+}
 
-func (t evaluatedJSONVariableDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
+func (d *evaluatedJSONVariableDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_evaluated_variable_json"
+}
+
+func (d *evaluatedJSONVariableDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Evaluated Variable data source. Each instance of this data source represents a single evaluated variable, under a single userdata context.",
 
-		Attributes: map[string]tfsdk.Attribute{
+		Attributes: evaluationSourceAttributes(map[string]schema.Attribute{
 			"user": userDataSchema(),
-			"value": {
+			"value": schema.StringAttribute{
 				MarkdownDescription: "Value of the Variable",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"default_value": {
+			"default_value": schema.StringAttribute{
 				MarkdownDescription: "Default value of the Variable. Used as a fallback in case there is no variation value set.",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"key": {
+			"key": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Variable ID or key. Recommended to use the key when not managing an entire project in Terraform.",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
-				},
-				Type: types.StringType,
 			},
-			"id": {
+			"id": schema.StringAttribute{
 				Computed: true,
-				Type:     types.StringType,
 			},
-		},
-	}, nil
-}
-
-func (t evaluatedJSONVariableDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return evaluatedJSONVariableDataSource{
-		provider: provider,
-	}, diags
+		}),
+	}
 }
 
 type evaluatedJSONVariableDataSourceData struct {
+	evaluationSourceData
 	Key          types.String                        `tfsdk:"key"`
 	Value        types.String                        `tfsdk:"value"`
 	User         evaluatedVariableDataSourceDataUser `tfsdk:"user"`
@@ -62,37 +58,34 @@ type evaluatedJSONVariableDataSourceData struct {
 }
 
 type evaluatedJSONVariableDataSource struct {
-	provider provider
+	evaluatedDataSourceBase
 }
 
-func (d evaluatedJSONVariableDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+func (d *evaluatedJSONVariableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data evaluatedJSONVariableDataSourceData
-	if !d.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
-		return
-	}
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := d.serverClient(ctx, data.evaluationSourceData, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	userData := dvc_server.DVCUser{
-		UserId: "" + data.User.Id.Value,
+		UserId: "" + data.User.Id.ValueString(),
 	}
 
-	defaultValueJSON := []byte(data.DefaultValue.Value)
+	defaultValueJSON := []byte(data.DefaultValue.ValueString())
 	var defaultValue map[string]any
 	err := json.Unmarshal(defaultValueJSON, &defaultValue)
 	if err != nil {
 		resp.Diagnostics.AddError("JSON Serialization Error", fmt.Sprintf("Unable to read Variable, got error: %s", err))
 		return
 	}
-	variable, err := d.provider.ServerClient.Variable(userData, data.Key.Value, defaultValue)
+	variable, err := client.Variable(userData, data.Key.ValueString(), defaultValue)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Variable, got error: %s", err))
 		return
@@ -104,10 +97,10 @@ func (d evaluatedJSONVariableDataSource) Read(ctx context.Context, req tfsdk.Rea
 		return
 	}
 
-	data.Key = types.String{Value: variable.Key}
+	data.Key = types.StringValue(variable.Key)
 	data.Id = data.Key
 
-	data.Value = types.String{Value: string(jsonstring)}
+	data.Value = types.StringValue(string(jsonstring))
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
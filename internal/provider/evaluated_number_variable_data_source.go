@@ -3,57 +3,53 @@ package provider
 import (
 	"context"
 	"fmt"
+	"math/big"
+
 	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"math/big"
 )
 
-type evaluatedNumberVariableDataSourceType struct{}
+var _ datasource.DataSourceWithConfigure = &evaluatedNumberVariableDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &evaluatedNumberVariableDataSource{}
+
+func newEvaluatedNumberVariableDataSource() datasource.DataSource {
+	return &evaluatedNumberVariableDataSource{}
+}
+
+func (d *evaluatedNumberVariableDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_evaluated_variable_number"
+}
 
-func (t evaluatedNumberVariableDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
+func (d *evaluatedNumberVariableDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Evaluated Variable data source. Each instance of this data source represents a single evaluated variable, under a single userdata context.",
 
-		Attributes: map[string]tfsdk.Attribute{
+		Attributes: evaluationSourceAttributes(map[string]schema.Attribute{
 			"user": userDataSchema(),
-			"value": {
+			"value": schema.NumberAttribute{
 				MarkdownDescription: "Value of the Variable",
 				Computed:            true,
-				Type:                types.NumberType,
 			},
-			"default_value": {
+			"default_value": schema.NumberAttribute{
 				MarkdownDescription: "Default value of the Variable. Used as a fallback in case there is no variation value set.",
 				Required:            true,
-				Type:                types.NumberType,
 			},
-			"key": {
+			"key": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Variable ID or key. Recommended to use the key when not managing an entire project in Terraform.",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
-				},
-				Type: types.StringType,
 			},
-			"id": {
+			"id": schema.StringAttribute{
 				Computed: true,
-				Type:     types.StringType,
 			},
-		},
-	}, nil
-}
-
-func (t evaluatedNumberVariableDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return evaluatedNumberVariableDataSource{
-		provider: provider,
-	}, diags
+		}),
+	}
 }
 
 type evaluatedNumberVariableDataSourceData struct {
+	evaluationSourceData
 	Key          types.String                        `tfsdk:"key"`
 	Value        types.Number                        `tfsdk:"value"`
 	User         evaluatedVariableDataSourceDataUser `tfsdk:"user"`
@@ -62,38 +58,35 @@ type evaluatedNumberVariableDataSourceData struct {
 }
 
 type evaluatedNumberVariableDataSource struct {
-	provider provider
+	evaluatedDataSourceBase
 }
 
-func (d evaluatedNumberVariableDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+func (d *evaluatedNumberVariableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data evaluatedNumberVariableDataSourceData
-	if !d.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
-		return
-	}
 	diags := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := d.serverClient(ctx, data.evaluationSourceData, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	userData := dvc_server.DVCUser{
-		UserId: "" + data.User.Id.Value,
+		UserId: "" + data.User.Id.ValueString(),
 	}
-	defaultValue, _ := data.DefaultValue.Value.Float64()
-	variable, err := d.provider.ServerClient.Variable(userData, data.Key.Value, defaultValue)
+	defaultValue, _ := data.DefaultValue.ValueBigFloat().Float64()
+	variable, err := client.Variable(userData, data.Key.ValueString(), defaultValue)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Variable, got error: %s", err))
 		return
 	}
 
-	data.Key = types.String{Value: variable.Key}
+	data.Key = types.StringValue(variable.Key)
 	data.Id = data.Key
-	data.Value = types.Number{Value: big.NewFloat(variable.Value.(float64))}
+	data.Value = types.NumberValue(big.NewFloat(variable.Value.(float64)))
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// projectExport is a project along with the objects exported with it.
+type projectExport struct {
+	Project      devcyclem.Project
+	Environments []devcyclem.Environment
+	Features     []featureWithStatus
+	Variables    []variableWithValidation
+	// ArchivedVariables are the keys of the archived variables of the
+	// project, which are not exported as devcycle_variable would unarchive
+	// them.
+	ArchivedVariables []string
+	// UnattachedVariables are the keys of the variables not attached to a
+	// feature, which devcycle_variable can't manage as it requires one.
+	UnattachedVariables []string
+}
+
+// ExportSettings are the credentials and HTTP settings of ExportProject. They
+// work like the provider attributes of the same name: credentials missing
+// from them are read from the DEVCYCLE_ACCESS_TOKEN, or DEVCYCLE_CLIENT_ID and
+// DEVCYCLE_CLIENT_SECRET environment variables, and the proxy defaults to the
+// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+type ExportSettings struct {
+	AccessToken  string
+	ClientID     string
+	ClientSecret string
+
+	ProxyURL   string
+	CACertFile string
+	// ClientCert and ClientKey are PEM encoded.
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+	// RequestTimeout is a duration, e.g. 30s.
+	RequestTimeout string
+}
+
+// ExportProject reads a project and its environments, features, variables and
+// variations from the management API and renders them as Terraform
+// configuration, along with the import blocks bringing the existing objects
+// under management. The configuration matches what the resources read after
+// the import, so the first plan has no changes. It returns the generated
+// files keyed by file name, and warnings about objects that were skipped.
+func ExportProject(ctx context.Context, settings ExportSettings, project string) (map[string][]byte, []string, error) {
+	data := providerData{
+		AccessToken:        types.StringValue(settings.AccessToken),
+		ClientId:           types.StringValue(settings.ClientID),
+		ClientSecret:       types.StringValue(settings.ClientSecret),
+		ProxyURL:           types.StringValue(settings.ProxyURL),
+		CACertFile:         types.StringValue(settings.CACertFile),
+		ClientCert:         types.StringValue(settings.ClientCert),
+		ClientKey:          types.StringValue(settings.ClientKey),
+		InsecureSkipVerify: types.BoolValue(settings.InsecureSkipVerify),
+		RequestTimeout:     types.StringValue(settings.RequestTimeout),
+	}
+	var diags diag.Diagnostics
+	transport := newHTTPSettings(data, &diags)
+	p := &devcycleProvider{version: "export"}
+	if !diags.HasError() {
+		p.authenticate(ctx, data, transport, &diags)
+	}
+	if err := diagnosticsError(diags); err != nil {
+		return nil, nil, err
+	}
+	if p.mgmtClientError != "" {
+		return nil, nil, fmt.Errorf("%s", p.mgmtClientError)
+	}
+	p.MgmtHTTPClient = newMgmtHTTPClient(transport)
+
+	export, err := fetchProjectExport(ctx, p, project)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []string
+	for _, warning := range diags.Warnings() {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", warning.Summary(), warning.Detail()))
+	}
+	for _, key := range export.ArchivedVariables {
+		warnings = append(warnings, fmt.Sprintf("variable %q is archived and was not exported", key))
+	}
+	for _, key := range export.UnattachedVariables {
+		warnings = append(warnings, fmt.Sprintf("variable %q isn't attached to a feature and was not exported", key))
+	}
+	return renderProjectExport(export), warnings, nil
+}
+
+// diagnosticsError returns the errors of diags as a single error, or nil when
+// there is none.
+func diagnosticsError(diags diag.Diagnostics) error {
+	if !diags.HasError() {
+		return nil
+	}
+	var messages []string
+	for _, d := range diags.Errors() {
+		messages = append(messages, fmt.Sprintf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "\n"))
+}
+
+func fetchProjectExport(ctx context.Context, p *devcycleProvider, project string) (projectExport, error) {
+	var export projectExport
+	if _, err := p.doMgmtJSONRequest(ctx, http.MethodGet, "/v1/projects/"+url.PathEscape(project), nil, nil, &export.Project); err != nil {
+		return export, fmt.Errorf("unable to read project %q: %w", project, err)
+	}
+	project = export.Project.Key
+
+	var err error
+	export.Environments, _, err = listMgmtPages[devcyclem.Environment](ctx, p, "/v1/projects/"+url.PathEscape(project)+"/environments", nil)
+	if err != nil {
+		return export, fmt.Errorf("unable to list the environments of project %q: %w", project, err)
+	}
+
+	features, _, err := listMgmtPages[featureWithStatus](ctx, p, featurePath(project, ""), nil)
+	if err != nil {
+		return export, fmt.Errorf("unable to list the features of project %q: %w", project, err)
+	}
+	// Each feature is read the way devcycle_feature reads it, so that the
+	// exported configuration matches the imported state.
+	for _, listed := range features {
+		var feature featureWithStatus
+		if _, err := p.doMgmtJSONRequest(ctx, http.MethodGet, featurePath(project, listed.Key), nil, nil, &feature); err != nil {
+			return export, fmt.Errorf("unable to read feature %q: %w", listed.Key, err)
+		}
+		export.Features = append(export.Features, feature)
+	}
+
+	variables, _, err := listMgmtPages[variableWithValidation](ctx, p, variablePath(project, ""), nil)
+	if err != nil {
+		return export, fmt.Errorf("unable to list the variables of project %q: %w", project, err)
+	}
+	for _, variable := range variables {
+		if variable.Status == variableStatusArchived {
+			export.ArchivedVariables = append(export.ArchivedVariables, variable.Key)
+			continue
+		}
+		if variable.Feature == "" {
+			export.UnattachedVariables = append(export.UnattachedVariables, variable.Key)
+			continue
+		}
+		export.Variables = append(export.Variables, variable)
+	}
+	sort.Strings(export.ArchivedVariables)
+	sort.Strings(export.UnattachedVariables)
+	sort.Slice(export.Variables, func(i, j int) bool {
+		return export.Variables[i].Key < export.Variables[j].Key
+	})
+
+	sort.Slice(export.Environments, func(i, j int) bool {
+		return export.Environments[i].Key < export.Environments[j].Key
+	})
+	sort.Slice(export.Features, func(i, j int) bool {
+		return export.Features[i].Key < export.Features[j].Key
+	})
+	return export, nil
+}
+
+// renderProjectExport renders the resources of the export in one file per
+// resource type, and their import blocks in imports.tf so that they can be
+// removed once imported.
+func renderProjectExport(export projectExport) map[string][]byte {
+	names := exportNames{}
+	imports := hclwrite.NewEmptyFile()
+	files := map[string]*hclwrite.File{}
+	addResource := func(file, resourceType, name, importID string) *hclwrite.Body {
+		f, ok := files[file]
+		if !ok {
+			f = hclwrite.NewEmptyFile()
+			files[file] = f
+		} else {
+			f.Body().AppendNewline()
+		}
+		if len(imports.Body().Blocks()) > 0 {
+			imports.Body().AppendNewline()
+		}
+		importBlock := imports.Body().AppendNewBlock("import", nil).Body()
+		importBlock.SetAttributeTraversal("to", exportTraversal(resourceType, name))
+		importBlock.SetAttributeValue("id", cty.StringVal(importID))
+		return f.Body().AppendNewBlock("resource", []string{resourceType, name}).Body()
+	}
+
+	project := export.Project
+	projectName := names.add("devcycle_project", project.Key)
+	body := addResource("project.tf", "devcycle_project", projectName, project.Key)
+	body.SetAttributeValue("key", cty.StringVal(project.Key))
+	body.SetAttributeValue("name", cty.StringVal(project.Name))
+	body.SetAttributeValue("description", cty.StringVal(project.Description))
+	projectID := exportTraversal("devcycle_project", projectName, "id")
+
+	for _, environment := range export.Environments {
+		name := names.add("devcycle_environment", environment.Key)
+		body := addResource("environments.tf", "devcycle_environment", name, project.Id+"/"+environment.Key)
+		body.SetAttributeTraversal("project_id", projectID)
+		body.SetAttributeValue("key", cty.StringVal(environment.Key))
+		body.SetAttributeValue("name", cty.StringVal(environment.Name))
+		body.SetAttributeValue("description", cty.StringVal(environment.Description))
+		body.SetAttributeValue("color", cty.StringVal(environment.Color))
+		body.SetAttributeValue("type", cty.StringVal(environment.Type_))
+		appIconURI := ""
+		if environment.Settings != nil {
+			appIconURI = environment.Settings.AppIconURI
+		}
+		body.SetAttributeValue("settings", cty.ObjectVal(map[string]cty.Value{
+			"app_icon_uri": cty.StringVal(appIconURI),
+		}))
+	}
+
+	// featureIDs references the exported features by ID, for the variables
+	// attached to them.
+	featureIDs := map[string]hcl.Traversal{}
+	for _, feature := range export.Features {
+		name := names.add("devcycle_feature", feature.Key)
+		featureIDs[feature.Id] = exportTraversal("devcycle_feature", name, "id")
+		body := addResource("features.tf", "devcycle_feature", name, project.Id+"/"+feature.Key)
+		body.SetAttributeTraversal("project_id", projectID)
+		body.SetAttributeValue("key", cty.StringVal(feature.Key))
+		body.SetAttributeValue("name", cty.StringVal(feature.Name))
+		body.SetAttributeValue("description", cty.StringVal(feature.Description))
+		body.SetAttributeValue("type", cty.StringVal(feature.Type_))
+		// An empty list of tags is kept as such, as it reads differently
+		// from tags that are not set.
+		if feature.Tags != nil {
+			body.SetAttributeValue("tags", exportStringList(feature.Tags))
+		}
+
+		var data featureResourceData
+		data.setStatus(feature)
+		if data.Status.ValueString() != featureStatusActive {
+			body.SetAttributeValue("status", cty.StringVal(data.Status.ValueString()))
+		}
+		if !data.StaticVariation.IsNull() {
+			body.SetAttributeValue("static_variation", cty.StringVal(data.StaticVariation.ValueString()))
+		}
+
+		featureKey := exportTraversal("devcycle_feature", name, "key")
+
+		variations := append([]devcyclem.Variation(nil), feature.Variations...)
+		sort.Slice(variations, func(i, j int) bool {
+			return variations[i].Key < variations[j].Key
+		})
+		for _, variation := range variations {
+			name := names.add("devcycle_variation", feature.Key+"_"+variation.Key)
+			body := addResource("variations.tf", "devcycle_variation", name, project.Id+"/"+feature.Key+"/"+variation.Key)
+			body.SetAttributeTraversal("project_id", projectID)
+			body.SetAttributeTraversal("feature_id", featureKey)
+			body.SetAttributeValue("key", cty.StringVal(variation.Key))
+			body.SetAttributeValue("name", cty.StringVal(variation.Name))
+			values := map[string]cty.Value{}
+			for key, value := range variationVariablesToTF(variation.Variables, feature.Variables) {
+				values[key] = cty.StringVal(value)
+			}
+			if len(values) == 0 {
+				body.SetAttributeValue("variables", cty.MapValEmpty(cty.String))
+			} else {
+				body.SetAttributeValue("variables", cty.MapVal(values))
+			}
+		}
+	}
+
+	// Variables are exported as devcycle_variable, the variables of the
+	// features are left unset.
+	for _, variable := range export.Variables {
+		name := names.add("devcycle_variable", variable.Key)
+		body := addResource("variables.tf", "devcycle_variable", name, project.Id+"/"+variable.Key)
+		body.SetAttributeTraversal("project_id", projectID)
+		if feature, ok := featureIDs[variable.Feature]; ok {
+			body.SetAttributeTraversal("feature_id", feature)
+		} else {
+			body.SetAttributeValue("feature_id", cty.StringVal(variable.Feature))
+		}
+		body.SetAttributeValue("key", cty.StringVal(variable.Key))
+		body.SetAttributeValue("name", cty.StringVal(variable.Name))
+		body.SetAttributeValue("description", cty.StringVal(variable.Description))
+		body.SetAttributeValue("type", cty.StringVal(variable.Type_))
+		if defaultValue := typedValueToTF(variable.Type_, variable.DefaultValue, types.StringNull()); !defaultValue.IsNull() {
+			body.SetAttributeValue("default_value", cty.StringVal(defaultValue.ValueString()))
+		}
+		if validation := validationSchemaToTF(variable.ValidationSchema, variable.Type_, nil); validation != nil {
+			body.SetAttributeValue("validation_schema", exportValidationSchema(*validation))
+		}
+	}
+
+	ret := map[string][]byte{"imports.tf": hclwrite.Format(imports.Bytes())}
+	for name, f := range files {
+		ret[name] = hclwrite.Format(f.Bytes())
+	}
+	return ret
+}
+
+// exportValidationSchema renders the attributes of a validation schema that are
+// set.
+func exportValidationSchema(validation variableResourceDataValidationSchema) cty.Value {
+	attributes := map[string]cty.Value{}
+	if !validation.EnumValues.IsNull() {
+		var values []string
+		for _, value := range validation.EnumValues.Elements() {
+			values = append(values, value.(types.String).ValueString())
+		}
+		attributes["enum_values"] = exportStringList(values)
+	}
+	if !validation.RegexPattern.IsNull() {
+		attributes["regex_pattern"] = cty.StringVal(validation.RegexPattern.ValueString())
+	}
+	if !validation.MinValue.IsNull() {
+		attributes["min_value"] = cty.NumberFloatVal(validation.MinValue.ValueFloat64())
+	}
+	if !validation.MaxValue.IsNull() {
+		attributes["max_value"] = cty.NumberFloatVal(validation.MaxValue.ValueFloat64())
+	}
+	if !validation.JSONSchema.IsNull() {
+		attributes["json_schema"] = cty.StringVal(validation.JSONSchema.ValueString())
+	}
+	if !validation.Description.IsNull() {
+		attributes["description"] = cty.StringVal(validation.Description.ValueString())
+	}
+	return cty.ObjectVal(attributes)
+}
+
+func exportStringList(values []string) cty.Value {
+	if len(values) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+	elems := make([]cty.Value, 0, len(values))
+	for _, value := range values {
+		elems = append(elems, cty.StringVal(value))
+	}
+	return cty.ListVal(elems)
+}
+
+func exportTraversal(resourceType, name string, attributes ...string) hcl.Traversal {
+	traversal := hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: name},
+	}
+	for _, attribute := range attributes {
+		traversal = append(traversal, hcl.TraverseAttr{Name: attribute})
+	}
+	return traversal
+}
+
+var exportInvalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// exportNames hands out unique resource names, per resource type.
+type exportNames map[string]bool
+
+// add returns a valid, unique resource name derived from key.
+func (n exportNames) add(resourceType, key string) string {
+	name := exportInvalidNameChars.ReplaceAllString(key, "_")
+	if name == "" || !(name[0] == '_' || name[0] >= 'a' && name[0] <= 'z' || name[0] >= 'A' && name[0] <= 'Z') {
+		name = "_" + name
+	}
+	unique := name
+	for i := 2; n[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	n[resourceType+"."+unique] = true
+	return unique
+}
@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+type exportTestTransport map[string]string
+
+func (t exportTestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, ok := t[req.URL.Path]
+	status := http.StatusOK
+	if !ok {
+		body, status = `{"message":"Not Found"}`, http.StatusNotFound
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestExportProject(t *testing.T) {
+	p := &devcycleProvider{MgmtHTTPClient: &http.Client{Transport: exportTestTransport{
+		"/v1/projects/my-project": `{"_id":"p1","key":"my-project","name":"My Project","description":"Has \"quotes\" and ${braces}"}`,
+		"/v1/projects/my-project/environments": `[
+			{"_id":"e2","key":"production","name":"Production","color":"#ff0000","type":"production","_project":"p1","settings":{"appIconURI":""}},
+			{"_id":"e1","key":"development","name":"Development","description":"Dev","color":"#00ff00","type":"development","_project":"p1"}
+		]`,
+		"/v1/projects/my-project/features": `[{"key":"checkout"},{"key":"2fa"}]`,
+		"/v1/projects/my-project/features/checkout": `{
+			"_id":"f1","key":"checkout","name":"Checkout","type":"release","_project":"p1","tags":["web"],
+			"variables":[
+				{"_id":"v2","key":"checkout-config","name":"Config","type":"JSON","_feature":"f1"},
+				{"_id":"v1","key":"checkout","name":"Checkout","description":"Enabled","type":"Boolean","_feature":"f1"}
+			],
+			"variations":[
+				{"_id":"vn2","key":"on","name":"On","variables":{"checkout":true,"checkout-config":{"b":1,"a":[2]}}},
+				{"_id":"vn1","key":"off","name":"Off","variables":{"checkout":false,"checkout-config":{}}}
+			]
+		}`,
+		"/v1/projects/my-project/features/2fa": `{
+			"_id":"f2","key":"2fa","name":"2FA","type":"permission","_project":"p1","tags":[],
+			"status":"complete","staticVariation":"vn3",
+			"variations":[{"_id":"vn3","key":"enabled","name":"Enabled"}]
+		}`,
+		"/v1/projects/my-project/variables": `[
+			{"_id":"v2","key":"checkout-config","name":"Config","type":"JSON","_feature":"f1","_project":"p1","defaultValue":{"a":[]}},
+			{"_id":"v1","key":"checkout","name":"Checkout","description":"Enabled","type":"Boolean","_feature":"f1","_project":"p1"},
+			{"_id":"v3","key":"orphan","name":"Orphan","type":"String","_project":"p1","defaultValue":"red","validationSchema":{"schemaType":"enum","enumValues":["red","blue"]}},
+			{"_id":"v5","key":"theme","name":"Theme","type":"String","_feature":"f2","_project":"p1","defaultValue":"red","validationSchema":{"schemaType":"enum","enumValues":["red","blue"]}},
+			{"_id":"v4","key":"old","name":"Old","type":"Boolean","_feature":"f1","_project":"p1","status":"archived"}
+		]`,
+	}}}
+
+	export, err := fetchProjectExport(context.Background(), p, "my-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(export.ArchivedVariables) != 1 || export.ArchivedVariables[0] != "old" {
+		t.Errorf("expected old to be reported as archived, got %v", export.ArchivedVariables)
+	}
+	if len(export.UnattachedVariables) != 1 || export.UnattachedVariables[0] != "orphan" {
+		t.Errorf("expected orphan to be reported as unattached, got %v", export.UnattachedVariables)
+	}
+
+	files := renderProjectExport(export)
+	expected := map[string]string{
+		"project.tf": `resource "devcycle_project" "my-project" {
+  key         = "my-project"
+  name        = "My Project"
+  description = "Has \"quotes\" and $${braces}"
+}
+`,
+		"environments.tf": `resource "devcycle_environment" "development" {
+  project_id  = devcycle_project.my-project.id
+  key         = "development"
+  name        = "Development"
+  description = "Dev"
+  color       = "#00ff00"
+  type        = "development"
+  settings = {
+    app_icon_uri = ""
+  }
+}
+
+resource "devcycle_environment" "production" {
+  project_id  = devcycle_project.my-project.id
+  key         = "production"
+  name        = "Production"
+  description = ""
+  color       = "#ff0000"
+  type        = "production"
+  settings = {
+    app_icon_uri = ""
+  }
+}
+`,
+		"features.tf": `resource "devcycle_feature" "_2fa" {
+  project_id       = devcycle_project.my-project.id
+  key              = "2fa"
+  name             = "2FA"
+  description      = ""
+  type             = "permission"
+  tags             = []
+  status           = "complete"
+  static_variation = "enabled"
+}
+
+resource "devcycle_feature" "checkout" {
+  project_id  = devcycle_project.my-project.id
+  key         = "checkout"
+  name        = "Checkout"
+  description = ""
+  type        = "release"
+  tags        = ["web"]
+}
+`,
+		"variations.tf": `resource "devcycle_variation" "_2fa_enabled" {
+  project_id = devcycle_project.my-project.id
+  feature_id = devcycle_feature._2fa.key
+  key        = "enabled"
+  name       = "Enabled"
+  variables  = {}
+}
+
+resource "devcycle_variation" "checkout_off" {
+  project_id = devcycle_project.my-project.id
+  feature_id = devcycle_feature.checkout.key
+  key        = "off"
+  name       = "Off"
+  variables = {
+    checkout        = "false"
+    checkout-config = "{}"
+  }
+}
+
+resource "devcycle_variation" "checkout_on" {
+  project_id = devcycle_project.my-project.id
+  feature_id = devcycle_feature.checkout.key
+  key        = "on"
+  name       = "On"
+  variables = {
+    checkout        = "true"
+    checkout-config = "{\"a\":[2],\"b\":1}"
+  }
+}
+`,
+		"imports.tf": `import {
+  to = devcycle_project.my-project
+  id = "my-project"
+}
+
+import {
+  to = devcycle_environment.development
+  id = "p1/development"
+}
+
+import {
+  to = devcycle_environment.production
+  id = "p1/production"
+}
+
+import {
+  to = devcycle_feature._2fa
+  id = "p1/2fa"
+}
+
+import {
+  to = devcycle_variation._2fa_enabled
+  id = "p1/2fa/enabled"
+}
+
+import {
+  to = devcycle_feature.checkout
+  id = "p1/checkout"
+}
+
+import {
+  to = devcycle_variation.checkout_off
+  id = "p1/checkout/off"
+}
+
+import {
+  to = devcycle_variation.checkout_on
+  id = "p1/checkout/on"
+}
+
+import {
+  to = devcycle_variable.checkout
+  id = "p1/checkout"
+}
+
+import {
+  to = devcycle_variable.checkout-config
+  id = "p1/checkout-config"
+}
+
+import {
+  to = devcycle_variable.theme
+  id = "p1/theme"
+}
+`,
+		"variables.tf": `resource "devcycle_variable" "checkout" {
+  project_id  = devcycle_project.my-project.id
+  feature_id  = devcycle_feature.checkout.id
+  key         = "checkout"
+  name        = "Checkout"
+  description = "Enabled"
+  type        = "Boolean"
+}
+
+resource "devcycle_variable" "checkout-config" {
+  project_id    = devcycle_project.my-project.id
+  feature_id    = devcycle_feature.checkout.id
+  key           = "checkout-config"
+  name          = "Config"
+  description   = ""
+  type          = "JSON"
+  default_value = "{\"a\":[]}"
+}
+
+resource "devcycle_variable" "theme" {
+  project_id    = devcycle_project.my-project.id
+  feature_id    = devcycle_feature._2fa.id
+  key           = "theme"
+  name          = "Theme"
+  description   = ""
+  type          = "String"
+  default_value = "red"
+  validation_schema = {
+    enum_values = ["red", "blue"]
+  }
+}
+`,
+	}
+
+	for name, want := range expected {
+		if got := string(files[name]); got != want {
+			t.Errorf("unexpected %s:\n%s\nexpected:\n%s", name, got, want)
+		}
+	}
+	if len(files) != len(expected) {
+		t.Errorf("expected %d files, got %d", len(expected), len(files))
+	}
+}
+
+func TestExportProjectSettings(t *testing.T) {
+	var connects []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		connects = append(connects, r.Method+" "+r.Host)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer proxy.Close()
+
+	_, _, err := ExportProject(context.Background(), ExportSettings{AccessToken: "token", ProxyURL: proxy.URL}, "my-project")
+	if err == nil {
+		t.Fatal("expected the export to fail behind the failing proxy")
+	}
+	if len(connects) == 0 || connects[0] != "CONNECT api.devcycle.com:443" {
+		t.Errorf("expected the requests to be sent through the proxy, got %v", connects)
+	}
+
+	_, _, err = ExportProject(context.Background(), ExportSettings{AccessToken: "token", RequestTimeout: "soon"}, "my-project")
+	if err == nil || !strings.Contains(err.Error(), "Invalid Request Timeout") {
+		t.Errorf("expected an invalid request timeout error, got %v", err)
+	}
+}
+
+// TestAccExportProject exports a project created by the first step and checks
+// that the exported configuration imports it without any change.
+func TestAccExportProject(t *testing.T) {
+	testAccPreCheck(t)
+	dir := t.TempDir()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExportProjectConfig(),
+			},
+			// The exported resources manage the objects created by the
+			// first step a second time, through their import blocks.
+			{
+				PreConfig:       func() { testAccWriteExport(t, dir) },
+				ConfigDirectory: config.StaticDirectory(dir),
+				PlanOnly:        true,
+			},
+			// The objects are destroyed without the import blocks.
+			{
+				Config: testAccExportProjectConfig(),
+			},
+		},
+	})
+}
+
+// testAccWriteExport writes the export of the project of
+// testAccExportProjectConfig to dir, along with that configuration.
+func testAccWriteExport(t *testing.T, dir string) {
+	t.Helper()
+	files, _, err := ExportProject(context.Background(), ExportSettings{}, "terraform-acceptance-testing-export"+randString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files["main.tf"] = []byte(testAccExportProjectConfig())
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), contents, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testAccExportProjectConfig() string {
+	return `
+resource "devcycle_project" "test" {
+  name = "TerraformAccTestExport` + randString + `"
+  key = "terraform-acceptance-testing-export` + randString + `"
+  description = "Terraform acceptance testing"
+}
+
+resource "devcycle_feature" "test" {
+  project_id = devcycle_project.test.id
+  name = "TerraformAccTestExport` + randString + `"
+  key = "terraform-acceptance-testing-export` + randString + `"
+  description = "Terraform acceptance testing"
+  type = "release"
+}
+
+resource "devcycle_variable" "test" {
+  project_id = devcycle_project.test.id
+  feature_id = devcycle_feature.test.id
+  name = "TerraformAccTestExport` + randString + `"
+  key = "terraform-acceptance-testing-export` + randString + `"
+  description = "Terraform acceptance testing"
+  type = "String"
+  default_value = "blue"
+  validation_schema = {
+    enum_values = ["blue", "green"]
+  }
+}
+`
+}
+
+func TestExportNames(t *testing.T) {
+	names := exportNames{}
+	for _, tc := range []struct {
+		resourceType, key, expected string
+	}{
+		{"devcycle_feature", "new-checkout", "new-checkout"},
+		{"devcycle_feature", "v1.2", "v1_2"},
+		{"devcycle_feature", "v1_2", "v1_2_2"},
+		{"devcycle_feature", "1-click", "_1-click"},
+		{"devcycle_variation", "v1.2", "v1_2"},
+	} {
+		if got := names.add(tc.resourceType, tc.key); got != tc.expected {
+			t.Errorf("add(%q, %q) = %q, expected %q", tc.resourceType, tc.key, got, tc.expected)
+		}
+	}
+}
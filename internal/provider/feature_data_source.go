@@ -2,139 +2,123 @@ package provider
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type featureDataSourceType struct{}
+var _ datasource.DataSourceWithConfigure = &featureDataSource{}
+
+func newFeatureDataSource() datasource.DataSource {
+	return &featureDataSource{}
+}
+
+func (d *featureDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature"
+}
 
-func (t featureDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
+func (d *featureDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "DevCycle Feature data source.",
 
-		Attributes: map[string]tfsdk.Attribute{
-			"name": {
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
 				MarkdownDescription: "Feature name",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"description": {
+			"description": schema.StringAttribute{
 				MarkdownDescription: "Feature description",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"key": {
+			"key": schema.StringAttribute{
 				MarkdownDescription: "Feature key",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"project_key": {
+			"project_key": schema.StringAttribute{
 				MarkdownDescription: "Project key that the feature belongs to",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"project_id": {
+			"project_id": schema.StringAttribute{
 				MarkdownDescription: "Project ID that the feature belongs to",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"type": {
+			"type": schema.StringAttribute{
 				MarkdownDescription: "Feature Type",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"variations": {
+			"variations": schema.ListNestedAttribute{
 				MarkdownDescription: "Feature variations",
 				Optional:            true,
-				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
-					"key": {
-						Type:                types.StringType,
-						Required:            true,
-						MarkdownDescription: "Variation key",
-					},
-					"name": {
-						Type:                types.StringType,
-						Required:            true,
-						MarkdownDescription: "Variation name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Variation key",
+						},
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Variation name",
+						},
+						"variables": schema.MapAttribute{
+							Required:            true,
+							MarkdownDescription: "Variation variables",
+							ElementType:         types.StringType,
+						},
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variation type",
+						},
 					},
-					"variables": {
-						Type:                types.MapType{ElemType: types.StringType},
-						Required:            true,
-						MarkdownDescription: "Variation variables",
-					},
-					"id": {
-						Type:                types.StringType,
-						Computed:            true,
-						MarkdownDescription: "Variation type",
-					},
-				}, tfsdk.ListNestedAttributesOptions{}),
+				},
 			},
-			"variables": {
+			"variables": schema.ListNestedAttribute{
 				MarkdownDescription: "Feature variables",
 				Optional:            true,
-				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
-					"name": {
-						Type:                types.StringType,
-						Optional:            true,
-						MarkdownDescription: "Variation name",
-					},
-					"description": {
-						Type:                types.StringType,
-						Optional:            true,
-						MarkdownDescription: "Variation feature key",
-					},
-					"key": {
-						Type:                types.StringType,
-						Required:            true,
-						MarkdownDescription: "Variation key",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Variation name",
+						},
+						"description": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Variation feature key",
+						},
+						"key": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Variation key",
+						},
+						"feature_key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variation feature key",
+						},
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Variation type",
+						},
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variation type",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Created at timestamp",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Updated at timestamp",
+						},
 					},
-					"feature_key": {
-						Type:                types.StringType,
-						Computed:            true,
-						MarkdownDescription: "Variation feature key",
-					},
-					"type": {
-						Type:                types.StringType,
-						Required:            true,
-						MarkdownDescription: "Variation type",
-					},
-					"id": {
-						Type:                types.StringType,
-						Computed:            true,
-						MarkdownDescription: "Variation type",
-					},
-					"created_at": {
-						Type:                types.StringType,
-						Computed:            true,
-						MarkdownDescription: "Created at timestamp",
-					},
-					"updated_at": {
-						Type:                types.StringType,
-						Computed:            true,
-						MarkdownDescription: "Updated at timestamp",
-					},
-				}, tfsdk.ListNestedAttributesOptions{}),
+				},
 			},
-			"id": {
+			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Feature ID",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
-				},
-				Type: types.StringType,
 			},
 		},
-	}, nil
-}
-
-func (t featureDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return featureDataSource{
-		provider: provider,
-	}, diags
+	}
 }
 
 type featureDataSourceData struct {
@@ -150,16 +134,12 @@ type featureDataSourceData struct {
 }
 
 type featureDataSource struct {
-	provider provider
+	dataSourceBase
 }
 
-func (d featureDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+func (d *featureDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data featureDataSourceData
-	if !d.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !d.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.Config.Get(ctx, &data)
@@ -169,20 +149,20 @@ func (d featureDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceReq
 		return
 	}
 
-	feature, httpResponse, err := d.provider.MgmtClient.FeaturesApi.FeaturesControllerFindOne(ctx, data.Key.Value, data.ProjectKey.Value)
+	feature, httpResponse, err := d.provider.MgmtClient.FeaturesApi.FeaturesControllerFindOne(ctx, data.Key.ValueString(), data.ProjectKey.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Id = types.String{Value: feature.Id}
-	data.Name = types.String{Value: feature.Name}
-	data.Key = types.String{Value: feature.Key}
-	data.Description = types.String{Value: feature.Description}
-	data.ProjectId = types.String{Value: feature.Project}
-	data.ProjectKey = types.String{Value: feature.Project}
-	data.Type = types.String{Value: feature.Type_}
+	data.Id = types.StringValue(feature.Id)
+	data.Name = types.StringValue(feature.Name)
+	data.Key = types.StringValue(feature.Key)
+	data.Description = types.StringValue(feature.Description)
+	data.ProjectId = types.StringValue(feature.Project)
+	data.ProjectKey = types.StringValue(feature.Project)
+	data.Type = types.StringValue(feature.Type_)
 	data.Variables = variableToTF(feature.Variables)
-	data.Variations = variationToTF(feature.Variations, data.Variables)
+	data.Variations = variationToTF(feature.Variations, feature.Variables)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -4,191 +4,668 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
 	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"sort"
-	"strconv"
 )
 
-type featureResourceType struct{}
+var _ resource.ResourceWithConfigure = &featureResource{}
+var _ resource.ResourceWithImportState = &featureResource{}
+var _ resource.ResourceWithUpgradeState = &featureResource{}
+var _ resource.ResourceWithValidateConfig = &featureResource{}
+var _ resource.ResourceWithModifyPlan = &featureResource{}
+
+func newFeatureResource() resource.Resource {
+	return &featureResource{}
+}
+
+func (r *featureResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature"
+}
+
+func (r *featureResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// Version 1 moved tags, variables and variations from lists to sets.
+		Version: 1,
 
-func (t featureResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "DevCycle Feature resource. It's recommended to use the variable resource instead of this resource to manage variables. Variations currently have to be managed in this resource.",
+		MarkdownDescription: "DevCycle Feature resource. It's recommended to use the variable resource instead of this resource to manage variables. Variations can be managed either in this resource or with the variation resource, in which case `variations` must be left unset.",
 
-		Attributes: map[string]tfsdk.Attribute{
-			"name": {
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
 				MarkdownDescription: "Feature name",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"description": {
+			"description": schema.StringAttribute{
 				MarkdownDescription: "Feature description",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"key": {
+			"key": schema.StringAttribute{
 				MarkdownDescription: "Feature key",
 				Required:            true,
-				Type:                types.StringType,
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"project_id": {
+			"project_id": schema.StringAttribute{
 				MarkdownDescription: "Project ID that the feature belongs to",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"type": {
+			"type": schema.StringAttribute{
 				MarkdownDescription: "Feature Type",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"source": {
+			"source": schema.StringAttribute{
 				MarkdownDescription: "Source of Feature creation",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"tags": {
+			"tags": schema.SetAttribute{
 				MarkdownDescription: "Feature tags",
 				Optional:            true,
-				Type:                types.ListType{ElemType: types.StringType},
+				ElementType:         types.StringType,
 			},
-			"variations": {
-				MarkdownDescription: "Feature variations",
+			"variations": schema.SetNestedAttribute{
+				MarkdownDescription: "Feature variations. Leave unset when managing variations with `devcycle_variation`. Variation keys must be unique and, when `variables` is set, each variation must set a value of the right type for every variable.",
 				Optional:            true,
-				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
-					"key": {
-						Type:                types.StringType,
-						Required:            true,
-						MarkdownDescription: "Variation key",
-					},
-					"name": {
-						Type:                types.StringType,
-						Required:            true,
-						MarkdownDescription: "Variation name",
-					},
-					"variables": {
-						Type:                types.MapType{ElemType: types.StringType},
-						Required:            true,
-						MarkdownDescription: "Variation variables",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Variation key",
+						},
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Variation name",
+						},
+						"variables": schema.MapAttribute{
+							Required:            true,
+							MarkdownDescription: "Variation variables",
+							ElementType:         types.StringType,
+						},
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variation type",
+						},
 					},
-					"id": {
-						Type:                types.StringType,
-						Computed:            true,
-						MarkdownDescription: "Variation type",
+				},
+			},
+			"variables": schema.SetNestedAttribute{
+				MarkdownDescription: "Feature variables. Leave unset when managing variables with `devcycle_variable`, the variables of the feature are then left untouched. Set to `[]` to remove the variables managed here. Removing the attribute stops managing the variables without removing them from the feature.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Variation name",
+						},
+						"description": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Variation feature key",
+						},
+						"key": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Variation key",
+						},
+						"feature_key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variation feature key",
+						},
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Variation type",
+						},
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variation type",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Created at timestamp",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Updated at timestamp",
+						},
 					},
-				}, tfsdk.ListNestedAttributesOptions{}),
+				},
 			},
-			"variables": {
-				MarkdownDescription: "Feature variables",
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Feature status, one of `active`, `complete` or `archived`. Completing a feature serves `static_variation` to all users in every environment.",
 				Optional:            true,
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.UseStateForUnknown(),
+				Computed:            true,
+				Validators: []validator.String{
+					stringOneOf(featureStatusActive, featureStatusComplete, featureStatusArchived),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 				},
-				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
-					"name": {
-						Type:                types.StringType,
+			},
+			"static_variation": schema.StringAttribute{
+				MarkdownDescription: "Key of the variation served everywhere once the feature is `complete`. Required when `status` is `complete`.",
+				Optional:            true,
+			},
+			"on_destroy": schema.StringAttribute{
+				MarkdownDescription: "What happens to the feature when it is destroyed. `delete` (the default) deletes the feature along with its variables, `archive` archives it and keeps its history.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(featureOnDestroyDelete, featureOnDestroyArchive),
+				},
+			},
+			"settings": schema.SingleNestedAttribute{
+				MarkdownDescription: "Feature settings. Leave unset to manage the settings from the dashboard.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"public_name": schema.StringAttribute{
+						MarkdownDescription: "Name of the feature shown to users when opting in",
 						Optional:            true,
-						MarkdownDescription: "Variation name",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
-					"description": {
-						Type:                types.StringType,
+					"public_description": schema.StringAttribute{
+						MarkdownDescription: "Description of the feature shown to users when opting in",
 						Optional:            true,
-						MarkdownDescription: "Variation feature key",
-					},
-					"key": {
-						Type:                types.StringType,
-						Required:            true,
-						MarkdownDescription: "Variation key",
-					},
-					"feature_key": {
-						Type:                types.StringType,
 						Computed:            true,
-						MarkdownDescription: "Variation feature key",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
 					},
-					"type": {
-						Type:                types.StringType,
-						Required:            true,
-						MarkdownDescription: "Variation type",
+					"opt_in_enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether users can opt in to the feature. Defaults to `false`.",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
 					},
-					"id": {
-						Type:                types.StringType,
+				},
+			},
+			"sdk_visibility": schema.SingleNestedAttribute{
+				MarkdownDescription: "SDK types the feature is visible to. Leave unset to manage the visibility from the dashboard.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"mobile": schema.BoolAttribute{
+						MarkdownDescription: "Whether the feature is visible to mobile SDKs. Defaults to `true`.",
+						Optional:            true,
 						Computed:            true,
-						MarkdownDescription: "Variation type",
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
 					},
-					"created_at": {
-						Type:                types.StringType,
+					"client": schema.BoolAttribute{
+						MarkdownDescription: "Whether the feature is visible to client SDKs. Defaults to `true`.",
+						Optional:            true,
 						Computed:            true,
-						MarkdownDescription: "Created at timestamp",
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
 					},
-					"updated_at": {
-						Type:                types.StringType,
+					"server": schema.BoolAttribute{
+						MarkdownDescription: "Whether the feature is visible to server SDKs. Defaults to `true`.",
+						Optional:            true,
 						Computed:            true,
-						MarkdownDescription: "Updated at timestamp",
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
 					},
-				}, tfsdk.ListNestedAttributesOptions{}),
+				},
 			},
-			"id": {
+			"etag": etagAttribute(),
+			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Feature ID",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
-				Type: types.StringType,
 			},
 		},
-	}, nil
+	}
 }
 
-func (t featureResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return featureResource{
-		provider: provider,
-	}, diags
+func (r *featureResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(
+		upgradeFeatureStateV0,
+	)
 }
 
 type featureResourceData struct {
-	Id          types.String                   `tfsdk:"id"`
-	Name        types.String                   `tfsdk:"name"`
-	Key         types.String                   `tfsdk:"key"`
-	Description types.String                   `tfsdk:"description"`
-	ProjectId   types.String                   `tfsdk:"project_id"`
-	Source      types.String                   `tfsdk:"source"`
-	Type        types.String                   `tfsdk:"type"`
-	Tags        []string                       `tfsdk:"tags"`
-	Variations  []featureResourceDataVariation `tfsdk:"variations"`
-	Variables   []featureResourceDataVariable  `tfsdk:"variables"`
-}
-
-func (t featureResourceData) variationToSDK() []devcyclem.FeatureVariationDto {
+	Id              types.String                   `tfsdk:"id"`
+	Name            types.String                   `tfsdk:"name"`
+	Key             types.String                   `tfsdk:"key"`
+	Description     types.String                   `tfsdk:"description"`
+	ProjectId       types.String                   `tfsdk:"project_id"`
+	Source          types.String                   `tfsdk:"source"`
+	Type            types.String                   `tfsdk:"type"`
+	Tags            []string                       `tfsdk:"tags"`
+	Variations      []featureResourceDataVariation `tfsdk:"variations"`
+	Variables       []featureResourceDataVariable  `tfsdk:"variables"`
+	Status          types.String                   `tfsdk:"status"`
+	StaticVariation types.String                   `tfsdk:"static_variation"`
+	OnDestroy       types.String                   `tfsdk:"on_destroy"`
+	Settings        *featureResourceDataSettings   `tfsdk:"settings"`
+	SDKVisibility   *featureResourceDataVisibility `tfsdk:"sdk_visibility"`
+	Etag            types.String                   `tfsdk:"etag"`
+}
+
+type featureResourceDataSettings struct {
+	PublicName        types.String `tfsdk:"public_name"`
+	PublicDescription types.String `tfsdk:"public_description"`
+	OptInEnabled      types.Bool   `tfsdk:"opt_in_enabled"`
+}
+
+type featureResourceDataVisibility struct {
+	Mobile types.Bool `tfsdk:"mobile"`
+	Client types.Bool `tfsdk:"client"`
+	Server types.Bool `tfsdk:"server"`
+}
+
+const (
+	featureStatusActive   = "active"
+	featureStatusComplete = "complete"
+	featureStatusArchived = "archived"
+
+	featureOnDestroyDelete  = "delete"
+	featureOnDestroyArchive = "archive"
+)
+
+// featureWithStatus extends the go-mgmt-sdk feature model with the feature
+// status and settings, which the generated client doesn't know about.
+type featureWithStatus struct {
+	devcyclem.Feature
+	Status          string                `json:"status,omitempty"`
+	StaticVariation string                `json:"staticVariation,omitempty"`
+	Settings        *featureSettings      `json:"settings,omitempty"`
+	SDKVisibility   *featureSDKVisibility `json:"sdkVisibility,omitempty"`
+}
+
+type featureSettings struct {
+	PublicName        string `json:"publicName"`
+	PublicDescription string `json:"publicDescription"`
+	OptInEnabled      bool   `json:"optInEnabled"`
+}
+
+type featureSDKVisibility struct {
+	Mobile bool `json:"mobile"`
+	Client bool `json:"client"`
+	Server bool `json:"server"`
+}
+
+type featureCreateDto struct {
+	devcyclem.CreateFeatureDto
+	Settings      *featureSettings      `json:"settings,omitempty"`
+	SDKVisibility *featureSDKVisibility `json:"sdkVisibility,omitempty"`
+}
+
+// featureWithSchemas extends the go-mgmt-sdk feature model with the
+// validation schemas of its variables by key, which the generated variable
+// model doesn't know about.
+type featureWithSchemas struct {
+	devcyclem.Feature
+	schemas map[string]*variableValidationSchema
+}
+
+func (f *featureWithSchemas) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &f.Feature); err != nil {
+		return err
+	}
+	var variables struct {
+		Variables []struct {
+			Key              string                    `json:"key"`
+			ValidationSchema *variableValidationSchema `json:"validationSchema"`
+		} `json:"variables"`
+	}
+	if err := json.Unmarshal(data, &variables); err != nil {
+		return err
+	}
+	f.schemas = make(map[string]*variableValidationSchema, len(variables.Variables))
+	for _, variable := range variables.Variables {
+		if variable.ValidationSchema != nil {
+			f.schemas[variable.Key] = variable.ValidationSchema
+		}
+	}
+	return nil
+}
+
+// featureVariableDto is a variable sent with a feature update. Unlike
+// devcyclem.CreateVariableDto it carries the validation schema, so that the
+// variables sent back as is keep theirs.
+type featureVariableDto struct {
+	devcyclem.CreateVariableDto
+	ValidationSchema *variableValidationSchema `json:"validationSchema,omitempty"`
+}
+
+// featureReplaceDto is a feature update sending the whole variable and
+// variation lists, see featureUpdateFromFeature.
+type featureReplaceDto struct {
+	Name        string                          `json:"name,omitempty"`
+	Key         string                          `json:"key,omitempty"`
+	Description string                          `json:"description,omitempty"`
+	Type_       string                          `json:"type,omitempty"`
+	Tags        []string                        `json:"tags,omitempty"`
+	Variables   []featureVariableDto            `json:"variables"`
+	Variations  []devcyclem.FeatureVariationDto `json:"variations"`
+}
+
+// featureUpdateDto is a partial feature update, only the fields that are set
+// are changed by the API.
+type featureUpdateDto struct {
+	Name          *string                          `json:"name,omitempty"`
+	Description   *string                          `json:"description,omitempty"`
+	Type_         *string                          `json:"type,omitempty"`
+	Tags          *[]string                        `json:"tags,omitempty"`
+	Variables     *[]featureVariableDto            `json:"variables,omitempty"`
+	Variations    *[]devcyclem.FeatureVariationDto `json:"variations,omitempty"`
+	Settings      *featureSettings                 `json:"settings,omitempty"`
+	SDKVisibility *featureSDKVisibility            `json:"sdkVisibility,omitempty"`
+}
+
+func (d featureUpdateDto) empty() bool {
+	return reflect.DeepEqual(d, featureUpdateDto{})
+}
+
+// updateDto returns the fields of the plan that differ from the prior state.
+// Variables and variations are sent as a whole by the API, so when they
+// change the remote lists are patched with the planned changes, keeping the
+// ones managed outside of Terraform. Variation values that can't be converted
+// to the type of their variable are reported to diags.
+func (t featureResourceData) updateDto(state featureResourceData, remote *featureWithSchemas, diags *diag.Diagnostics) featureUpdateDto {
+	var update featureUpdateDto
+	if t.Name.ValueString() != state.Name.ValueString() {
+		update.Name = t.Name.ValueStringPointer()
+	}
+	if t.Description.ValueString() != state.Description.ValueString() {
+		update.Description = t.Description.ValueStringPointer()
+	}
+	if t.Type.ValueString() != state.Type.ValueString() {
+		update.Type_ = t.Type.ValueStringPointer()
+	}
+	if !reflect.DeepEqual(t.Tags, state.Tags) && !(len(t.Tags) == 0 && len(state.Tags) == 0) {
+		tags := t.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		update.Tags = &tags
+	}
+
+	settings, visibility := t.settingsToSDK()
+	stateSettings, stateVisibility := state.settingsToSDK()
+	if settings != nil && (stateSettings == nil || *settings != *stateSettings) {
+		update.Settings = settings
+	}
+	if visibility != nil && (stateVisibility == nil || *visibility != *stateVisibility) {
+		update.SDKVisibility = visibility
+	}
+
+	if remote != nil && t.variablesChanged(state) {
+		variables := t.mergeVariables(state, *remote)
+		if variables == nil {
+			variables = []featureVariableDto{}
+		}
+		update.Variables = &variables
+	}
+	if remote != nil && (t.variablesChanged(state) || t.variationsChanged(state)) {
+		variations := t.mergeVariations(state, *remote, diags)
+		if variations == nil {
+			variations = []devcyclem.FeatureVariationDto{}
+		}
+		update.Variations = &variations
+	}
+	return update
+}
+
+// variablesChanged reports whether the inline variables differ between the
+// plan and the prior state. Unmanaged variables never change.
+func (t featureResourceData) variablesChanged(state featureResourceData) bool {
+	if t.Variables == nil {
+		return false
+	}
+	if len(t.Variables) != len(state.Variables) {
+		return true
+	}
+	prior := make(map[string]featureResourceDataVariable, len(state.Variables))
+	for _, variable := range state.Variables {
+		prior[variable.Key.ValueString()] = variable
+	}
+	for _, variable := range t.Variables {
+		old, ok := prior[variable.Key.ValueString()]
+		if !ok || old.Name.ValueString() != variable.Name.ValueString() || old.Description.ValueString() != variable.Description.ValueString() || old.Type.ValueString() != variable.Type.ValueString() {
+			return true
+		}
+	}
+	return false
+}
+
+// variationsChanged reports whether the inline variations differ between the
+// plan and the prior state. Unmanaged variations never change.
+func (t featureResourceData) variationsChanged(state featureResourceData) bool {
+	if t.Variations == nil {
+		return false
+	}
+	if len(t.Variations) != len(state.Variations) {
+		return true
+	}
+	prior := make(map[string]featureResourceDataVariation, len(state.Variations))
+	for _, variation := range state.Variations {
+		prior[variation.Key.ValueString()] = variation
+	}
+	for _, variation := range t.Variations {
+		old, ok := prior[variation.Key.ValueString()]
+		if !ok || old.Name.ValueString() != variation.Name.ValueString() || !reflect.DeepEqual(old.Variables, variation.Variables) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeVariables applies the planned variable changes to the remote
+// variables: variables removed from the configuration are dropped, planned
+// variables are added or replaced and any other variable is kept as is.
+// Validation schemas, which are managed with devcycle_variable, are carried
+// over.
+func (t featureResourceData) mergeVariables(state featureResourceData, remote featureWithSchemas) []featureVariableDto {
+	planned := make(map[string]bool, len(t.Variables))
+	for _, variable := range t.Variables {
+		planned[variable.Key.ValueString()] = true
+	}
+	removed := make(map[string]bool)
+	for _, variable := range state.Variables {
+		if !planned[variable.Key.ValueString()] {
+			removed[variable.Key.ValueString()] = true
+		}
+	}
+
+	kept := featureUpdateFromFeature(remote, func(v devcyclem.Variable) bool {
+		return !planned[v.Key] && !removed[v.Key]
+	}, nil)
+	for _, variable := range t.variablesToSDK() {
+		kept.Variables = append(kept.Variables, featureVariableDto{
+			CreateVariableDto: variable,
+			ValidationSchema:  remote.schemas[variable.Key],
+		})
+	}
+	return kept.Variables
+}
+
+// mergeVariations is the variation counterpart of mergeVariables. Remote
+// variations are kept untouched when the variations are unmanaged.
+func (t featureResourceData) mergeVariations(state featureResourceData, remote featureWithSchemas, diags *diag.Diagnostics) []devcyclem.FeatureVariationDto {
+	planned := make(map[string]bool, len(t.Variations))
+	for _, variation := range t.Variations {
+		planned[variation.Key.ValueString()] = true
+	}
+	removed := make(map[string]bool)
+	if t.Variations != nil {
+		for _, variation := range state.Variations {
+			if !planned[variation.Key.ValueString()] {
+				removed[variation.Key.ValueString()] = true
+			}
+		}
+	}
+
+	kept := featureUpdateFromFeature(remote, nil, func(v devcyclem.Variation) bool {
+		return !planned[v.Key] && !removed[v.Key]
+	})
+
+	// The planned values are sent as is. Values the plan leaves out for
+	// variables managed outside of the resource are carried over.
+	managedVariables := make(map[string]bool)
+	for _, variable := range append(t.Variables, state.Variables...) {
+		managedVariables[variable.Key.ValueString()] = true
+	}
+	variations := t.variationToSDK(remote.Variables, diags)
+	for i, variation := range t.Variations {
+		if existing, ok := findVariation(remote.Feature, variation.Key.ValueString()); ok {
+			for key, value := range existing.Variables {
+				if _, planned := variation.Variables[key]; !planned && !managedVariables[key] {
+					variations[i].Variables[key] = value
+				}
+			}
+		}
+	}
+	return append(kept.Variations, variations...)
+}
+
+// settingsToSDK returns the settings and SDK visibility to send to the API.
+// Attributes left unset get the API defaults, or keep their prior value
+// through UseStateForUnknown.
+func (t *featureResourceData) settingsToSDK() (*featureSettings, *featureSDKVisibility) {
+	var settings *featureSettings
+	if t.Settings != nil {
+		settings = &featureSettings{
+			PublicName:        t.Settings.PublicName.ValueString(),
+			PublicDescription: t.Settings.PublicDescription.ValueString(),
+			OptInEnabled:      t.Settings.OptInEnabled.ValueBool(),
+		}
+	}
+	var visibility *featureSDKVisibility
+	if t.SDKVisibility != nil {
+		visibility = &featureSDKVisibility{Mobile: true, Client: true, Server: true}
+		if !t.SDKVisibility.Mobile.IsUnknown() && !t.SDKVisibility.Mobile.IsNull() {
+			visibility.Mobile = t.SDKVisibility.Mobile.ValueBool()
+		}
+		if !t.SDKVisibility.Client.IsUnknown() && !t.SDKVisibility.Client.IsNull() {
+			visibility.Client = t.SDKVisibility.Client.ValueBool()
+		}
+		if !t.SDKVisibility.Server.IsUnknown() && !t.SDKVisibility.Server.IsNull() {
+			visibility.Server = t.SDKVisibility.Server.ValueBool()
+		}
+	}
+	return settings, visibility
+}
+
+// setSettings copies the settings of the feature into the resource data.
+// Settings left unset in the configuration stay unmanaged.
+func (t *featureResourceData) setSettings(feature featureWithStatus) {
+	if t.Settings != nil {
+		settings := featureSettings{}
+		if feature.Settings != nil {
+			settings = *feature.Settings
+		}
+		t.Settings = &featureResourceDataSettings{
+			PublicName:        types.StringValue(settings.PublicName),
+			PublicDescription: types.StringValue(settings.PublicDescription),
+			OptInEnabled:      types.BoolValue(settings.OptInEnabled),
+		}
+	}
+	if t.SDKVisibility != nil {
+		visibility := featureSDKVisibility{Mobile: true, Client: true, Server: true}
+		if feature.SDKVisibility != nil {
+			visibility = *feature.SDKVisibility
+		}
+		t.SDKVisibility = &featureResourceDataVisibility{
+			Mobile: types.BoolValue(visibility.Mobile),
+			Client: types.BoolValue(visibility.Client),
+			Server: types.BoolValue(visibility.Server),
+		}
+	}
+}
+
+// setStatus copies the status of the feature into the resource data. The
+// static variation is only kept while the feature is complete.
+func (t *featureResourceData) setStatus(feature featureWithStatus) {
+	status := feature.Status
+	if status == "" {
+		status = featureStatusActive
+	}
+	t.Status = types.StringValue(status)
+	if status == featureStatusComplete && feature.StaticVariation != "" {
+		staticVariation := feature.StaticVariation
+		// The API references the static variation by ID, report its key.
+		for _, variation := range feature.Variations {
+			if variation.Id == staticVariation {
+				staticVariation = variation.Key
+			}
+		}
+		t.StaticVariation = types.StringValue(staticVariation)
+	} else {
+		t.StaticVariation = types.StringNull()
+	}
+}
+
+// variationToSDK converts the planned variations, typing their values with the
+// planned variables or, for the variables managed outside of the resource,
+// e.g. with devcycle_variable, with the given remote variables.
+func (t featureResourceData) variationToSDK(remote []devcyclem.Variable, diags *diag.Diagnostics) []devcyclem.FeatureVariationDto {
+	variables := t.variablesToSDKTypes(remote)
 	var variations []devcyclem.FeatureVariationDto
 	for _, variation := range t.Variations {
 		variations = append(variations, devcyclem.FeatureVariationDto{
-			Key:       variation.Key.Value,
-			Name:      variation.Name.Value,
-			Variables: variation.variationMapTypeFix(t.Variables),
+			Key:       variation.Key.ValueString(),
+			Name:      variation.Name.ValueString(),
+			Variables: variationVariablesToSDK(variationsValuePath, variation.Variables, variables, diags),
 		})
 	}
 	return variations
 }
 
+// variationsValuePath is the path the errors on the variation values of a
+// feature are reported on. The set element of a variation can't be addressed
+// from its decoded value, so errors are reported on the whole attribute.
+func variationsValuePath(string) path.Path {
+	return path.Root("variations")
+}
+
+// variablesToSDKTypes returns the remote variables with the planned variables
+// in place of the remote ones of the same key.
+func (t featureResourceData) variablesToSDKTypes(remote []devcyclem.Variable) []devcyclem.Variable {
+	planned := make(map[string]bool, len(t.Variables))
+	var variables []devcyclem.Variable
+	for _, variable := range t.Variables {
+		planned[variable.Key.ValueString()] = true
+		variables = append(variables, devcyclem.Variable{Key: variable.Key.ValueString(), Type_: variable.Type.ValueString()})
+	}
+	for _, variable := range remote {
+		if !planned[variable.Key] {
+			variables = append(variables, variable)
+		}
+	}
+	return variables
+}
+
 func (t featureResourceData) variablesToSDK() []devcyclem.CreateVariableDto {
 	var variables []devcyclem.CreateVariableDto
 	for _, variable := range t.Variables {
 		nvar := devcyclem.CreateVariableDto{
-			Name:        variable.Name.Value,
-			Description: variable.Description.Value,
-			Key:         variable.Key.Value,
-			Feature:     t.Key.Value,
-			Type_:       variable.Type.Value,
+			Name:        variable.Name.ValueString(),
+			Description: variable.Description.ValueString(),
+			Key:         variable.Key.ValueString(),
+			Feature:     t.Key.ValueString(),
+			Type_:       variable.Type.ValueString(),
 		}
 		variables = append(variables, nvar)
 	}
@@ -213,92 +690,55 @@ type featureResourceDataVariation struct {
 	Variables map[string]string `tfsdk:"variables"`
 }
 
-func variationMapToString(variations map[string]interface{}, variables []featureResourceDataVariable) map[string]string {
-	ret := make(map[string]string)
-	for k, v := range variations {
-		for _, variable := range variables {
-			if variable.Key.Value == k {
-				switch variable.Type.Value {
-				case "String":
-					ret[k] = v.(string)
-					break
-				case "Number":
-					ret[k] = fmt.Sprintf("%f", v.(float64))
-					break
-				case "Boolean":
-					ret[k] = fmt.Sprintf("%t", v.(bool))
-					break
-				case "JSON":
-					marshal, err := json.Marshal(v)
-					if err != nil {
-						tflog.Error(context.Background(), "Error parsing json", err)
-						return nil
-					}
-					ret[k] = string(marshal)
-					break
-				}
-			}
+func variationToTF(variations []devcyclem.Variation, variables []devcyclem.Variable) []featureResourceDataVariation {
+	var ret []featureResourceDataVariation
+	for _, variation := range variations {
+		nvar := featureResourceDataVariation{
+			Key:       types.StringValue(variation.Key),
+			Name:      types.StringValue(variation.Name),
+			Variables: variationVariablesToTF(variation.Variables, variables),
+			Id:        types.StringValue(variation.Id),
 		}
-		ret[k] = fmt.Sprintf("%v", v)
+		ret = append(ret, nvar)
 	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Key.ValueString() < ret[j].Key.ValueString()
+	})
 	return ret
 }
 
-func (f *featureResourceDataVariation) variationMapTypeFix(variables []featureResourceDataVariable) map[string]interface{} {
-	ret := make(map[string]interface{})
-
-	for k, v := range f.Variables {
-		for _, variable := range variables {
-			if variable.Key.Value == k {
-				switch variable.Type.Value {
-				case "String":
-					ret[k] = v
-					break
-				case "JSON":
-					var marshalled interface{}
-					err := json.Unmarshal([]byte(v), &marshalled)
-					if err != nil {
-						tflog.Error(context.Background(), "Error parsing json", err)
-						return nil
-					}
-					break
-				case "Number":
-					float, err := strconv.ParseFloat(v, 64)
-					if err != nil {
-						tflog.Error(context.Background(), "Error parsing float", err)
-						return nil
-					}
-					ret[k] = float
-					break
-				case "Boolean":
-					b, err := strconv.ParseBool(v)
-					if err != nil {
-						tflog.Error(context.Background(), "Error parsing bool", err)
-						return nil
-					}
-					ret[k] = b
-					break
-				}
-			}
+// managedVariablesToTF converts the remote variables, keeping only the ones
+// managed by the resource. Variables left unset are managed outside of the
+// resource, e.g. with devcycle_variable, and stay unset.
+func managedVariablesToTF(vars []devcyclem.Variable, managed []featureResourceDataVariable) []featureResourceDataVariable {
+	if managed == nil {
+		return nil
+	}
+	keys := make(map[string]bool, len(managed))
+	for _, variable := range managed {
+		keys[variable.Key.ValueString()] = true
+	}
+	ret := []featureResourceDataVariable{}
+	for _, variable := range variableToTF(vars) {
+		if keys[variable.Key.ValueString()] {
+			ret = append(ret, variable)
 		}
 	}
 	return ret
 }
 
-func variationToTF(variations []devcyclem.Variation, variables []featureResourceDataVariable) []featureResourceDataVariation {
-	var ret []featureResourceDataVariation
-	for _, variation := range variations {
-		nvar := featureResourceDataVariation{
-			Key:       types.String{Value: variation.Key},
-			Name:      types.String{Value: variation.Name},
-			Variables: variationMapToString(variation.Variables, variables),
-			Id:        types.String{Value: variation.Id},
+// managedVariationsToTF is the variation counterpart of managedVariablesToTF.
+func managedVariationsToTF(variations []devcyclem.Variation, managed []featureResourceDataVariation, variables []devcyclem.Variable) []featureResourceDataVariation {
+	keys := make(map[string]bool, len(managed))
+	for _, variation := range managed {
+		keys[variation.Key.ValueString()] = true
+	}
+	ret := []featureResourceDataVariation{}
+	for _, variation := range variationToTF(variations, variables) {
+		if len(managed) == 0 || keys[variation.Key.ValueString()] {
+			ret = append(ret, variation)
 		}
-		ret = append(ret, nvar)
 	}
-	sort.Slice(ret, func(i, j int) bool {
-		return ret[i].Key.Value < ret[j].Key.Value
-	})
 	return ret
 }
 
@@ -306,68 +746,334 @@ func variableToTF(vars []devcyclem.Variable) []featureResourceDataVariable {
 	var ret []featureResourceDataVariable
 	for _, variable := range vars {
 		nvar := featureResourceDataVariable{
-			Key:         types.String{Value: variable.Key},
-			Name:        types.String{Value: variable.Name},
-			Description: types.String{Value: variable.Description},
-			FeatureKey:  types.String{Value: variable.Feature},
-			Type:        types.String{Value: variable.Type_},
-			Id:          types.String{Value: variable.Id},
-			CreatedAt:   types.String{Value: variable.CreatedAt.String()},
-			UpdatedAt:   types.String{Value: variable.UpdatedAt.String()},
+			Key:         types.StringValue(variable.Key),
+			Name:        types.StringValue(variable.Name),
+			Description: types.StringValue(variable.Description),
+			FeatureKey:  types.StringValue(variable.Feature),
+			Type:        types.StringValue(variable.Type_),
+			Id:          types.StringValue(variable.Id),
+			CreatedAt:   types.StringValue(variable.CreatedAt.String()),
+			UpdatedAt:   types.StringValue(variable.UpdatedAt.String()),
 		}
 		ret = append(ret, nvar)
 	}
 
 	sort.Slice(ret, func(i, j int) bool {
-		return ret[i].Key.Value < ret[j].Key.Value
+		return ret[i].Key.ValueString() < ret[j].Key.ValueString()
 	})
 	return ret
 }
 
 type featureResource struct {
-	provider provider
+	resourceBase
 }
 
-func (r featureResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
-	var data featureResourceData
+func (r *featureResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var status, staticVariation types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("status"), &status)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("static_variation"), &staticVariation)...)
+	if resp.Diagnostics.HasError() || status.IsUnknown() || staticVariation.IsUnknown() {
+		return
+	}
 
-	diags := req.Config.Get(ctx, &data)
-	resp.Diagnostics.Append(diags...)
+	if status.ValueString() == featureStatusComplete && staticVariation.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("static_variation"),
+			"Missing Static Variation",
+			"static_variation must be set when status is complete.",
+		)
+	}
+	if status.ValueString() != featureStatusComplete && !staticVariation.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("static_variation"),
+			"Unexpected Static Variation",
+			"static_variation can only be set when status is complete.",
+		)
+	}
 
+	var variables, variations types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("variables"), &variables)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("variations"), &variations)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+
+	validateFeatureVariations(ctx, variables, variations, &resp.Diagnostics)
+}
+
+// validateFeatureVariations checks that the inline variations of a feature
+// have unique keys and, when the variables are managed inline too, that every
+// variation sets a value of the right type for each variable and nothing else.
+// Unknown values are skipped, they are validated once known.
+func validateFeatureVariations(ctx context.Context, variables, variations types.Set, diags *diag.Diagnostics) {
+	if variations.IsNull() || variations.IsUnknown() {
+		return
+	}
+
+	// variableTypes is nil when the variables aren't all known, e.g. when
+	// they are managed with devcycle_variable.
+	var variableTypes map[string]types.String
+	if !variables.IsNull() && !variables.IsUnknown() {
+		variableTypes = map[string]types.String{}
+		for _, elem := range variables.Elements() {
+			obj, ok := elem.(types.Object)
+			if !ok || obj.IsUnknown() {
+				variableTypes = nil
+				break
+			}
+			var variable featureResourceDataVariable
+			diags.Append(obj.As(ctx, &variable, basetypes.ObjectAsOptions{})...)
+			if diags.HasError() || !isSetString(variable.Key) {
+				variableTypes = nil
+				break
+			}
+			key := variable.Key.ValueString()
+			if _, ok := variableTypes[key]; ok {
+				diags.AddAttributeError(
+					path.Root("variables").AtSetValue(obj).AtName("key"),
+					"Duplicate Variable Key",
+					fmt.Sprintf("Variable key %q is used by more than one variable.", key),
+				)
+			}
+			variableTypes[key] = variable.Type
+		}
+	}
+
+	variationKeys := map[string]bool{}
+	for _, elem := range variations.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsUnknown() {
+			continue
+		}
+		var variation featureVariationPlan
+		diags.Append(obj.As(ctx, &variation, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return
+		}
+		variationPath := path.Root("variations").AtSetValue(obj)
+
+		if isSetString(variation.Key) {
+			key := variation.Key.ValueString()
+			if variationKeys[key] {
+				diags.AddAttributeError(
+					variationPath.AtName("key"),
+					"Duplicate Variation Key",
+					fmt.Sprintf("Variation key %q is used by more than one variation.", key),
+				)
+			}
+			variationKeys[key] = true
+		}
+
+		if variableTypes == nil || variation.Variables.IsNull() || variation.Variables.IsUnknown() {
+			continue
+		}
+
+		values := variation.Variables.Elements()
+		for key, value := range values {
+			variableType, ok := variableTypes[key]
+			if !ok {
+				diags.AddAttributeError(
+					variationPath.AtName("variables").AtMapKey(key),
+					"Unknown Variable",
+					fmt.Sprintf("Variable %q is not defined in the variables of the feature.", key),
+				)
+				continue
+			}
+			str, ok := value.(types.String)
+			if !ok || !isSetString(str) || !isSetString(variableType) {
+				continue
+			}
+			if _, err := parseTypedValue(variableType.ValueString(), str.ValueString()); err != nil {
+				diags.AddAttributeError(
+					variationPath.AtName("variables").AtMapKey(key),
+					"Invalid Variable Value",
+					fmt.Sprintf("Unable to convert value %q of variable %q to %s: %s", str.ValueString(), key, variableType.ValueString(), err),
+				)
+			}
+		}
+
+		var missing []string
+		for key := range variableTypes {
+			if _, ok := values[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			diags.AddAttributeError(
+				variationPath.AtName("variables"),
+				"Missing Variable Value",
+				fmt.Sprintf("Variation %q doesn't set a value for variables %s. Every variation must set a value for each variable of the feature.", variation.Key.ValueString(), strings.Join(missing, ", ")),
+			)
+		}
+	}
+}
+
+func (r *featureResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate when the feature is being destroyed, and the
+	// variables can only be looked up once the provider is configured.
+	if req.Plan.Raw.IsNull() || !r.mgmtClientReady() {
+		return
+	}
+
+	r.warnRemoteKeyCollision(ctx, req, resp, featureKeyCheck)
+	r.warnVariablesManagedElsewhere(ctx, req, resp)
+
+	var projectId types.String
+	var variables, variations types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("project_id"), &projectId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("variables"), &variables)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("variations"), &variations)...)
+	if resp.Diagnostics.HasError() || !isSetString(projectId) || variables.IsUnknown() || variations.IsNull() || variations.IsUnknown() {
 		return
 	}
 
-	feature, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeaturesControllerCreate(ctx, devcyclem.CreateFeatureDto{
-		Name:        data.Name.Value,
-		Key:         data.Key.Value,
-		Description: data.Description.Value,
-		Variations:  data.variationToSDK(),
-		Variables:   data.variablesToSDK(),
-		Type_:       data.Type.Value,
-		Tags:        data.Tags,
-	}, data.ProjectId.Value)
+	r.provider.validateVariationValues(ctx, projectId.ValueString(), variables, variations, &resp.Diagnostics)
+}
+
+// warnVariablesManagedElsewhere warns when the feature has variables that
+// aren't listed in its inline variables, which means that the feature is
+// managed both inline and with devcycle_variable or from the dashboard. Those
+// variables are preserved on update.
+func (r *featureResource) warnVariablesManagedElsewhere(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var projectId, key types.String
+	var planned types.Set
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("project_id"), &projectId)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("key"), &key)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("variables"), &planned)...)
+	if resp.Diagnostics.HasError() || planned.IsNull() || planned.IsUnknown() {
+		return
+	}
+
+	var plannedVariables []featureResourceDataVariable
+	if diags := planned.ElementsAs(ctx, &plannedVariables, false); diags.HasError() {
+		return
+	}
+	plannedKeys := make(map[string]bool, len(plannedVariables))
+	for _, variable := range plannedVariables {
+		plannedKeys[variable.Key.ValueString()] = true
+	}
+
+	var feature devcyclem.Feature
+	httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodGet, featurePath(projectId.ValueString(), key.ValueString()), nil, nil, &feature)
+	if err != nil || httpResponse.StatusCode != http.StatusOK {
+		// Reported by Read, the warning is best effort.
+		return
+	}
+
+	var external []string
+	for _, variable := range feature.Variables {
+		if !plannedKeys[variable.Key] {
+			external = append(external, variable.Key)
+		}
+	}
+	if len(external) == 0 {
+		return
+	}
+	sort.Strings(external)
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("variables"),
+		"Feature Variables Managed in Multiple Places",
+		fmt.Sprintf("Variables %s are attached to feature %q but not listed in `variables`, they are likely managed with devcycle_variable or from the dashboard. "+
+			"They are left untouched, but managing each variable in a single place is recommended, e.g. by removing `variables` from this feature.",
+			strings.Join(external, ", "), key.ValueString()),
+	)
+}
+
+// projectVariables looks up the project variables the planned variations set
+// a value for that aren't planned inline, e.g. variables created from the
+// dashboard before being added to the feature. Variables that can't be looked
+// up are reported as unknown when converting the variations.
+func (r *featureResource) projectVariables(ctx context.Context, data featureResourceData) []devcyclem.Variable {
+	inline := make(map[string]bool, len(data.Variables))
+	for _, variable := range data.Variables {
+		inline[variable.Key.ValueString()] = true
+	}
+	var variables []devcyclem.Variable
+	for _, variation := range data.Variations {
+		for key := range variation.Variables {
+			if inline[key] {
+				continue
+			}
+			inline[key] = true
+			var variable devcyclem.Variable
+			httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodGet, variablePath(data.ProjectId.ValueString(), key), nil, nil, &variable)
+			if err == nil && httpResponse.StatusCode == http.StatusOK {
+				variables = append(variables, variable)
+			}
+		}
+	}
+	return variables
+}
+
+func (r *featureResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data featureResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plannedStatus, plannedStaticVariation := data.Status, data.StaticVariation
+
+	variations := data.variationToSDK(r.projectVariables(ctx, data), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	settings, visibility := data.settingsToSDK()
+	var feature featureWithStatus
+	httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodPost, featurePath(data.ProjectId.ValueString(), ""), nil, featureCreateDto{
+		CreateFeatureDto: devcyclem.CreateFeatureDto{
+			Name:        data.Name.ValueString(),
+			Key:         data.Key.ValueString(),
+			Description: data.Description.ValueString(),
+			Variations:  variations,
+			Variables:   data.variablesToSDK(),
+			Type_:       data.Type.ValueString(),
+			Tags:        data.Tags,
+		},
+		Settings:      settings,
+		SDKVisibility: visibility,
+	}, &feature)
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Id = types.String{Value: feature.Id}
-	data.Key = types.String{Value: feature.Key}
-	data.Name = types.String{Value: feature.Name}
-	data.Description = types.String{Value: feature.Description}
-	data.Type = types.String{Value: feature.Type_}
+	data.Id = types.StringValue(feature.Id)
+	data.Key = types.StringValue(feature.Key)
+	data.Name = types.StringValue(feature.Name)
+	data.Description = types.StringValue(feature.Description)
+	data.Type = types.StringValue(feature.Type_)
 	data.Tags = feature.Tags
-	data.ProjectId = types.String{Value: feature.Project}
-	data.Source = types.String{Value: feature.Source}
-	data.Variables = variableToTF(feature.Variables)
-	data.Variations = variationToTF(feature.Variations, data.Variables)
+	data.ProjectId = types.StringValue(feature.Project)
+	data.Source = types.StringValue(feature.Source)
+	data.Variables = managedVariablesToTF(feature.Variables, data.Variables)
+	// Variations left unset are managed outside of this resource, e.g. with
+	// devcycle_variation.
+	if data.Variations != nil {
+		data.Variations = managedVariationsToTF(feature.Variations, data.Variations, feature.Variables)
+	}
+
+	data.setSettings(feature)
+	data.setStatus(feature)
+	data.Etag = etagToTF(httpResponse)
+	if plannedStatus.ValueString() != "" && plannedStatus.ValueString() != featureStatusActive {
+		updated, httpResponse, ret := r.provider.setFeatureStatus(ctx, data.Key.ValueString(), data.ProjectId.ValueString(), plannedStatus.ValueString(), plannedStaticVariation.ValueString(), &resp.Diagnostics)
+		if ret {
+			return
+		}
+		data.StaticVariation = plannedStaticVariation
+		data.setStatus(updated)
+		data.Etag = etagToTF(httpResponse)
+	}
 
 	// write logs using the tflog package
 	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
@@ -378,13 +1084,9 @@ func (r featureResource) Create(ctx context.Context, req tfsdk.CreateResourceReq
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r featureResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+func (r *featureResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data featureResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.State.Get(ctx, &data)
@@ -394,33 +1096,39 @@ func (r featureResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest
 		return
 	}
 
-	feature, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeaturesControllerFindOne(ctx, data.Key.Value, data.ProjectId.Value)
+	var feature featureWithStatus
+	httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodGet, featurePath(data.ProjectId.ValueString(), data.Key.ValueString()), nil, nil, &feature)
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Id = types.String{Value: feature.Id}
-	data.Key = types.String{Value: feature.Key}
-	data.Name = types.String{Value: feature.Name}
-	data.Description = types.String{Value: feature.Description}
-	data.Type = types.String{Value: feature.Type_}
+	data.setStatus(feature)
+	data.setSettings(feature)
+	data.Etag = etagToTF(httpResponse)
+	data.Id = types.StringValue(feature.Id)
+	data.Key = types.StringValue(feature.Key)
+	data.Name = types.StringValue(feature.Name)
+	data.Description = types.StringValue(feature.Description)
+	data.Type = types.StringValue(feature.Type_)
 	data.Tags = feature.Tags
-	data.ProjectId = types.String{Value: feature.Project}
-	data.Source = types.String{Value: feature.Source}
-	data.Variables = variableToTF(feature.Variables)
-	data.Variations = variationToTF(feature.Variations, data.Variables)
+	data.ProjectId = types.StringValue(feature.Project)
+	data.Source = types.StringValue(feature.Source)
+	// Imported features have no managed variables, their variables stay
+	// unmanaged until the configuration lists them.
+	data.Variables = managedVariablesToTF(feature.Variables, data.Variables)
+	// Variations left unset are managed outside of this resource, e.g. with
+	// devcycle_variation.
+	if data.Variations != nil {
+		data.Variations = managedVariationsToTF(feature.Variations, data.Variations, feature.Variables)
+	}
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r featureResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+func (r *featureResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data featureResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.Plan.Get(ctx, &data)
@@ -430,41 +1138,80 @@ func (r featureResource) Update(ctx context.Context, req tfsdk.UpdateResourceReq
 		return
 	}
 
-	feature, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeaturesControllerUpdate(ctx, devcyclem.UpdateFeatureDto{
-		Name:        data.Name.Value,
-		Key:         data.Key.Value,
-		Description: data.Description.Value,
-		Type_:       data.Type.Value,
-		Tags:        data.Tags,
-		Variables:   data.variablesToSDK(),
-		Variations:  data.variationToSDK(),
-	}, data.Key.Value, data.ProjectId.Value)
+	var state featureResourceData
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plannedStatus, plannedStaticVariation := data.Status, data.StaticVariation
+
+	// Only the changed fields are sent so that changes made outside of
+	// Terraform, e.g. variables added by devcycle_variable, are preserved.
+	var feature featureWithStatus
+	var remote *featureWithSchemas
+	if data.variablesChanged(state) || data.variationsChanged(state) {
+		var current featureWithSchemas
+		httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodGet, featurePath(data.ProjectId.ValueString(), data.Key.ValueString()), nil, nil, &current)
+		if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+			return
+		}
+		remote = &current
+	}
+	update := data.updateDto(state, remote, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	method, body, writeCtx := http.MethodPatch, interface{}(update), withIfMatch(ctx, state.Etag)
+	if update.empty() {
+		method, body, writeCtx = http.MethodGet, nil, ctx
+	}
+	httpResponse, err := r.provider.doMgmtJSONRequest(writeCtx, method, featurePath(data.ProjectId.ValueString(), data.Key.ValueString()), nil, body, &feature)
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Id = types.String{Value: feature.Id}
-	data.Key = types.String{Value: feature.Key}
-	data.Name = types.String{Value: feature.Name}
-	data.Description = types.String{Value: feature.Description}
-	data.Type = types.String{Value: feature.Type_}
+	data.Id = types.StringValue(feature.Id)
+	data.Key = types.StringValue(feature.Key)
+	data.Name = types.StringValue(feature.Name)
+	data.Description = types.StringValue(feature.Description)
+	data.Type = types.StringValue(feature.Type_)
 	data.Tags = feature.Tags
-	data.ProjectId = types.String{Value: feature.Project}
-	data.Source = types.String{Value: feature.Source}
-	data.Variables = variableToTF(feature.Variables)
-	data.Variations = variationToTF(feature.Variations, data.Variables)
+	data.ProjectId = types.StringValue(feature.Project)
+	data.Source = types.StringValue(feature.Source)
+	data.Variables = managedVariablesToTF(feature.Variables, data.Variables)
+	// Variations left unset are managed outside of this resource, e.g. with
+	// devcycle_variation.
+	if data.Variations != nil {
+		data.Variations = managedVariationsToTF(feature.Variations, data.Variations, feature.Variables)
+	}
+
+	data.setSettings(feature)
+	data.Etag = etagToTF(httpResponse)
+	// Without a prior write the status change is checked against the etag
+	// of the state rather than the one that was just read.
+	statusEtag := data.Etag
+	if update.empty() {
+		statusEtag = state.Etag
+	}
+	data.Status, data.StaticVariation = state.Status, state.StaticVariation
+	if !plannedStatus.IsUnknown() && (plannedStatus.ValueString() != state.Status.ValueString() || plannedStaticVariation.ValueString() != state.StaticVariation.ValueString()) {
+		updated, httpResponse, ret := r.provider.setFeatureStatus(withIfMatch(ctx, statusEtag), data.Key.ValueString(), data.ProjectId.ValueString(), plannedStatus.ValueString(), plannedStaticVariation.ValueString(), &resp.Diagnostics)
+		if ret {
+			return
+		}
+		data.StaticVariation = plannedStaticVariation
+		data.setStatus(updated)
+		data.Etag = etagToTF(httpResponse)
+	}
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r featureResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+func (r *featureResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data featureResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.State.Get(ctx, &data)
@@ -474,14 +1221,24 @@ func (r featureResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReq
 		return
 	}
 
+	if data.OnDestroy.ValueString() == featureOnDestroyArchive {
+		if data.Status.ValueString() != featureStatusArchived {
+			if _, _, ret := r.provider.setFeatureStatus(withIfMatch(ctx, data.Etag), data.Key.ValueString(), data.ProjectId.ValueString(), featureStatusArchived, "", &resp.Diagnostics); ret {
+				return
+			}
+		}
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	// Ask the API to delete associated variables along with the feature.
-	if ret := r.provider.featureControllerDelete(ctx, data.Key.Value, data.ProjectId.Value, &resp.Diagnostics); ret {
+	if ret := r.provider.featureControllerDelete(withIfMatch(ctx, data.Etag), data.Key.ValueString(), data.ProjectId.ValueString(), &resp.Diagnostics); ret {
 		return
 	}
 
 	resp.State.RemoveResource(ctx)
 }
 
-func (r featureResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+func (r *featureResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importProjectScopedKey(ctx, "project_id", req, resp)
 }
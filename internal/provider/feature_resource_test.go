@@ -1,9 +1,26 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccFeatureResource(t *testing.T) {
@@ -24,6 +41,12 @@ func TestAccFeatureResource(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("devcycle_feature.test", "project_id", "622112634cabe0e9fbaf974d"),
 					resource.TestCheckResourceAttr("devcycle_feature.test", "description", "Terraform acceptance testing edited"),
+					resource.TestCheckResourceAttr("devcycle_feature.test", "settings.public_name", "Terraform Acceptance Testing"),
+					resource.TestCheckResourceAttr("devcycle_feature.test", "settings.public_description", ""),
+					resource.TestCheckResourceAttr("devcycle_feature.test", "settings.opt_in_enabled", "true"),
+					resource.TestCheckResourceAttr("devcycle_feature.test", "sdk_visibility.mobile", "false"),
+					resource.TestCheckResourceAttr("devcycle_feature.test", "sdk_visibility.client", "true"),
+					resource.TestCheckResourceAttr("devcycle_feature.test", "sdk_visibility.server", "true"),
 				),
 			},
 			{
@@ -34,6 +57,145 @@ func TestAccFeatureResource(t *testing.T) {
 	})
 }
 
+func TestAccFeatureResourceStatus(t *testing.T) {
+	testAccPreCheck(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFeatureResourceStatusConfig(`status = "active"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_feature.status", "status", "active"),
+					resource.TestCheckNoResourceAttr("devcycle_feature.status", "static_variation"),
+				),
+			},
+			{
+				Config: testAccFeatureResourceStatusConfig(`
+  status = "complete"
+  static_variation = "test-variation-key` + randString + `"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_feature.status", "status", "complete"),
+					resource.TestCheckResourceAttr("devcycle_feature.status", "static_variation", "test-variation-key"+randString),
+				),
+			},
+			{
+				Config:      testAccFeatureResourceStatusConfig(`status = "complete"`),
+				ExpectError: regexp.MustCompile("Missing Static Variation"),
+			},
+			{
+				Config: testAccFeatureResourceStatusConfig(`status = "archived"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_feature.status", "status", "archived"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccFeatureResourceWithVariableResource checks that a feature whose
+// variables are managed with devcycle_variable leaves them untouched.
+func TestAccFeatureResourceWithVariableResource(t *testing.T) {
+	testAccPreCheck(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFeatureResourceWithVariableResourceConfig("Terraform acceptance testing"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("devcycle_feature.attached", "variables.#"),
+					resource.TestCheckResourceAttr("devcycle_variable.attached", "key", "test-attached-variable-key"+randString),
+				),
+			},
+			{
+				Config:   testAccFeatureResourceWithVariableResourceConfig("Terraform acceptance testing"),
+				PlanOnly: true,
+			},
+			// Updating the feature keeps the validation schema of its variable.
+			{
+				Config: testAccFeatureResourceWithVariableResourceConfig("Updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_feature.attached", "description", "Updated"),
+					resource.TestCheckResourceAttr("devcycle_variable.attached", "validation_schema.enum_values.#", "2"),
+				),
+			},
+			{
+				Config:   testAccFeatureResourceWithVariableResourceConfig("Updated"),
+				PlanOnly: true,
+			},
+			// Importing the feature leaves the variable to devcycle_variable.
+			{
+				ResourceName:  "devcycle_feature.attached",
+				ImportState:   true,
+				ImportStateId: "622112634cabe0e9fbaf974d/terraform-acceptance-testing-attached" + randString,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if count, ok := states[0].Attributes["variables.#"]; ok {
+						return fmt.Errorf("expected the variables of the imported feature to be unset, got %s", count)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccFeatureResourceWithVariableResourceConfig(description string) string {
+	return `
+resource "devcycle_feature" "attached" {
+  project_id = "622112634cabe0e9fbaf974d"
+  name = "TerraformAccTestAttached` + randString + `"
+  key = "terraform-acceptance-testing-attached` + randString + `"
+  description = "` + description + `"
+  type = "release"
+}
+
+resource "devcycle_variable" "attached" {
+  name = "test-attached-variable-name` + randString + `"
+  key = "test-attached-variable-key` + randString + `"
+  description = "Terraform acceptance testing"
+  type = "String"
+  feature_id = devcycle_feature.attached.id
+  project_id = "622112634cabe0e9fbaf974d"
+  default_value = "blue"
+  validation_schema = {
+    enum_values = ["blue", "green"]
+  }
+}
+`
+}
+
+func testAccFeatureResourceStatusConfig(status string) string {
+	return `
+resource "devcycle_feature" "status" {
+  project_id = "622112634cabe0e9fbaf974d"
+  name = "TerraformAccTestStatus` + randString + `"
+  key = "terraform-acceptance-testing-status` + randString + `"
+  description = "Terraform acceptance testing"
+  type = "release"
+  on_destroy = "archive"
+  ` + status + `
+  variables = [
+	{
+	  name = "test-status-variable-name` + randString + `"
+	  description = "description"
+      key = "test-status-variable-key` + randString + `"
+      type = "Boolean"
+	}
+  ]
+  variations = [
+	{
+		key = "test-variation-key` + randString + `"
+		name = "test-variation-name` + randString + `"
+		variables = {
+			"test-status-variable-key` + randString + `" = "true"
+		}
+	}
+  ]
+}
+`
+}
+
 func testAccFeatureResourceConfig() string {
 	return `
 resource "devcycle_feature" "test" {
@@ -77,6 +239,13 @@ resource "devcycle_feature" "test" {
   description = "Terraform acceptance testing edited"
   type = "experiment"
   tags = ["acceptance-testing"]
+  settings = {
+    public_name = "Terraform Acceptance Testing"
+    opt_in_enabled = true
+  }
+  sdk_visibility = {
+    mobile = false
+  }
   variables = [
 	{
 	  name = "test-variable-name` + randString + `"
@@ -108,3 +277,322 @@ output "testing" {
 }
 `
 }
+
+func TestFeatureResourceDataUpdateDto(t *testing.T) {
+	variable := func(key, name string) featureResourceDataVariable {
+		return featureResourceDataVariable{
+			Key:  types.StringValue(key),
+			Name: types.StringValue(name),
+			Type: types.StringValue("Boolean"),
+		}
+	}
+	state := featureResourceData{
+		Key:         types.StringValue("feature"),
+		Name:        types.StringValue("Feature"),
+		Description: types.StringValue("description"),
+		Type:        types.StringValue("release"),
+		Tags:        []string{"a"},
+		Variables:   []featureResourceDataVariable{variable("managed", "Managed"), variable("removed", "Removed")},
+		Variations: []featureResourceDataVariation{{
+			Key:       types.StringValue("on"),
+			Name:      types.StringValue("On"),
+			Variables: map[string]string{"managed": "true", "removed": "true"},
+		}},
+	}
+	var remote featureWithSchemas
+	if err := json.Unmarshal([]byte(`{
+		"key": "feature",
+		"variables": [
+			{"key": "managed", "name": "Managed", "type": "Boolean"},
+			{"key": "removed", "name": "Removed", "type": "Boolean"},
+			{"key": "external", "name": "External", "type": "Boolean", "validationSchema": {"schemaType": "enum", "enumValues": [true]}}
+		],
+		"variations": [
+			{"key": "on", "name": "On", "variables": {"managed": true, "removed": true, "external": true}},
+			{"key": "dashboard", "name": "Dashboard", "variables": {"external": false}}
+		]
+	}`), &remote); err != nil {
+		t.Fatal(err)
+	}
+
+	var diags diag.Diagnostics
+	t.Run("unchanged", func(t *testing.T) {
+		if update := state.updateDto(state, &remote, &diags); !update.empty() {
+			t.Errorf("expected an empty update, got %+v", update)
+		}
+	})
+
+	t.Run("description only", func(t *testing.T) {
+		plan := state
+		plan.Description = types.StringValue("edited")
+		update := plan.updateDto(state, &remote, &diags)
+		if update.Description == nil || *update.Description != "edited" {
+			t.Errorf("expected description to be updated, got %+v", update.Description)
+		}
+		if update.Name != nil || update.Tags != nil || update.Variables != nil || update.Variations != nil {
+			t.Errorf("expected only the description to be sent, got %+v", update)
+		}
+	})
+
+	t.Run("cleared tags", func(t *testing.T) {
+		plan := state
+		plan.Tags = nil
+		update := plan.updateDto(state, &remote, &diags)
+		if update.Tags == nil || len(*update.Tags) != 0 {
+			t.Errorf("expected tags to be cleared, got %+v", update.Tags)
+		}
+	})
+
+	t.Run("removed variable", func(t *testing.T) {
+		plan := state
+		plan.Variables = []featureResourceDataVariable{variable("managed", "Managed")}
+		plan.Variations = []featureResourceDataVariation{{
+			Key:       types.StringValue("on"),
+			Name:      types.StringValue("On"),
+			Variables: map[string]string{"managed": "true"},
+		}}
+		update := plan.updateDto(state, &remote, &diags)
+
+		if update.Variables == nil {
+			t.Fatalf("expected the variables to be sent, got %+v", update)
+		}
+		variables := map[string]bool{}
+		for _, v := range *update.Variables {
+			variables[v.Key] = true
+			if v.Key == "external" && (v.ValidationSchema == nil || v.ValidationSchema.SchemaType != "enum") {
+				t.Errorf("expected the validation schema of the external variable to be kept, got %+v", v.ValidationSchema)
+			}
+		}
+		if !variables["managed"] || !variables["external"] || variables["removed"] {
+			t.Errorf("expected managed and external variables to be kept, got %+v", update.Variables)
+		}
+
+		if update.Variations == nil {
+			t.Fatalf("expected the variations to be sent, got %+v", update)
+		}
+		variations := map[string]devcyclem.FeatureVariationDto{}
+		for _, v := range *update.Variations {
+			variations[v.Key] = v
+		}
+		if _, ok := variations["dashboard"]; !ok {
+			t.Errorf("expected the dashboard variation to be kept, got %+v", update.Variations)
+		}
+		on := variations["on"].Variables
+		if on["managed"] != true || on["external"] != true {
+			t.Errorf("expected variation values to be merged, got %+v", on)
+		}
+		if _, ok := on["removed"]; ok {
+			t.Errorf("expected removed variable value to be dropped, got %+v", on)
+		}
+	})
+
+	t.Run("externally managed variable values", func(t *testing.T) {
+		plan := state
+		plan.Variations = []featureResourceDataVariation{{
+			Key:       types.StringValue("on"),
+			Name:      types.StringValue("Renamed"),
+			Variables: map[string]string{"managed": "true", "removed": "true", "external": "false"},
+		}}
+		update := plan.updateDto(state, &remote, &diags)
+		if diags.HasError() {
+			t.Fatalf("unexpected errors: %v", diags)
+		}
+		if update.Variations == nil {
+			t.Fatalf("expected the variations to be sent, got %+v", update)
+		}
+		for _, variation := range *update.Variations {
+			if variation.Key == "on" && variation.Variables["external"] != false {
+				t.Errorf("expected the planned value of the external variable to be sent, got %+v", variation.Variables)
+			}
+		}
+
+		plan.Variations[0].Variables["external"] = "not a boolean"
+		plan.updateDto(state, &remote, &diags)
+		if !diags.HasError() {
+			t.Error("expected an invalid value to be reported")
+		}
+		diags = nil
+	})
+
+	t.Run("unmanaged variables", func(t *testing.T) {
+		plan := state
+		plan.Variables = nil
+		if update := plan.updateDto(state, &remote, &diags); update.Variables != nil {
+			t.Errorf("expected unset variables to be left untouched, got %+v", *update.Variables)
+		}
+	})
+
+	t.Run("cleared variables", func(t *testing.T) {
+		plan := state
+		plan.Variables = []featureResourceDataVariable{}
+		plan.Variations = nil
+		onlyManaged := remote
+		onlyManaged.Variables = remote.Variables[:2]
+		update := plan.updateDto(state, &onlyManaged, &diags)
+		body, err := json.Marshal(update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(body), `"variables":[]`) {
+			t.Errorf("expected an empty variable list to be sent, got %s", body)
+		}
+	})
+
+	t.Run("cleared variations", func(t *testing.T) {
+		plan := state
+		plan.Variations = []featureResourceDataVariation{}
+		onlyManaged := remote
+		onlyManaged.Variations = remote.Variations[:1]
+		update := plan.updateDto(state, &onlyManaged, &diags)
+		body, err := json.Marshal(update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(body), `"variations":[]`) {
+			t.Errorf("expected an empty variation list to be sent, got %s", body)
+		}
+	})
+}
+
+func TestValidateFeatureVariations(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp fwresource.SchemaResponse
+	newFeatureResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	variablesType := schemaResp.Schema.Attributes["variables"].GetType().(types.SetType).ElemType
+	variationsType := schemaResp.Schema.Attributes["variations"].GetType().(types.SetType).ElemType
+
+	variable := func(key, variableType string) featureResourceDataVariable {
+		return featureResourceDataVariable{
+			Key:         types.StringValue(key),
+			Type:        types.StringValue(variableType),
+			Name:        types.StringNull(),
+			Description: types.StringNull(),
+			Id:          types.StringUnknown(),
+			FeatureKey:  types.StringUnknown(),
+			CreatedAt:   types.StringUnknown(),
+			UpdatedAt:   types.StringUnknown(),
+		}
+	}
+	variation := func(key string, values map[string]string) featureVariationPlan {
+		return featureVariationPlan{
+			Id:   types.StringUnknown(),
+			Key:  types.StringValue(key),
+			Name: types.StringValue(key),
+			Variables: types.MapValueMust(types.StringType, func() map[string]attr.Value {
+				ret := map[string]attr.Value{}
+				for k, v := range values {
+					ret[k] = types.StringValue(v)
+				}
+				return ret
+			}()),
+		}
+	}
+	set := func(elemType attr.Type, elems interface{}) types.Set {
+		value, diags := types.SetValueFrom(ctx, elemType, elems)
+		if diags.HasError() {
+			t.Fatalf("unable to build set: %v", diags)
+		}
+		return value
+	}
+
+	variables := set(variablesType, []featureResourceDataVariable{variable("enabled", "Boolean"), variable("limit", "Number")})
+
+	cases := []struct {
+		name       string
+		variables  types.Set
+		variations []featureVariationPlan
+		errors     []string
+	}{
+		{"valid", variables, []featureVariationPlan{
+			variation("on", map[string]string{"enabled": "true", "limit": "10"}),
+			variation("off", map[string]string{"enabled": "false", "limit": "0"}),
+		}, nil},
+		{"unknown variable", variables, []featureVariationPlan{
+			variation("on", map[string]string{"enabled": "true", "limit": "10", "other": "x"}),
+		}, []string{"Unknown Variable"}},
+		{"missing variable", variables, []featureVariationPlan{
+			variation("on", map[string]string{"enabled": "true"}),
+		}, []string{"Missing Variable Value"}},
+		{"type mismatch", variables, []featureVariationPlan{
+			variation("on", map[string]string{"enabled": "yes", "limit": "10"}),
+		}, []string{"Invalid Variable Value"}},
+		{"duplicate variation key", variables, []featureVariationPlan{
+			variation("on", map[string]string{"enabled": "true", "limit": "10"}),
+			variation("on", map[string]string{"enabled": "false", "limit": "10"}),
+		}, []string{"Duplicate Variation Key"}},
+		{"variables managed elsewhere", types.SetNull(variablesType), []featureVariationPlan{
+			variation("on", map[string]string{"anything": "x"}),
+		}, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateFeatureVariations(ctx, c.variables, set(variationsType, c.variations), &diags)
+			var summaries []string
+			for _, d := range diags {
+				summaries = append(summaries, d.Summary())
+			}
+			if !reflect.DeepEqual(summaries, c.errors) {
+				t.Errorf("expected errors %v, got %v", c.errors, diags)
+			}
+			for _, d := range diags {
+				if withPath, ok := d.(diag.DiagnosticWithPath); !ok || len(withPath.Path().Steps()) < 2 {
+					t.Errorf("expected error to point at a variation attribute, got %v", d)
+				}
+			}
+		})
+	}
+}
+
+func TestFeatureResourceCreateFromPlan(t *testing.T) {
+	ctx := context.Background()
+	var requests []string
+	p := &devcycleProvider{MgmtHTTPClient: &http.Client{Transport: mgmtTestTransport(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Status:     http.StatusText(http.StatusCreated),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body: io.NopCloser(strings.NewReader(`{"_id": "feature-id", "_project": "project", "key": "feature", "name": "Feature", "type": "release", "source": "api",
+				"variables": [{"_id": "variable-id", "key": "variable", "type": "Boolean"}]}`)),
+			Request: req,
+		}, nil
+	})}}
+	r := &featureResource{resourceBase{providerBase{provider: p}}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: remoteValidationObject(t, r, map[string]string{
+		"key":        "feature",
+		"name":       "Feature",
+		"project_id": "project",
+		"type":       "release",
+	})}
+	// Computed attributes left unset in the configuration are unknown.
+	for _, name := range []string{"id", "source", "status", "etag"} {
+		attributeType := schemaResp.Schema.Attributes[name].GetType().TerraformType(ctx)
+		if diags := plan.SetAttribute(ctx, path.Root(name), tftypes.NewValue(attributeType, tftypes.UnknownValue)); diags.HasError() {
+			t.Fatal(diags)
+		}
+	}
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+	if !reflect.DeepEqual(requests, []string{"POST /v1/projects/project/features"}) {
+		t.Errorf("expected only the feature to be created, got %v", requests)
+	}
+
+	var variables types.Set
+	resp.State.GetAttribute(ctx, path.Root("variables"), &variables)
+	if !variables.IsNull() {
+		t.Errorf("expected the unset variables to stay null, got %v", variables)
+	}
+	var id types.String
+	resp.State.GetAttribute(ctx, path.Root("id"), &id)
+	if id.ValueString() != "feature-id" {
+		t.Errorf("expected the created feature ID, got %v", id)
+	}
+}
@@ -0,0 +1,625 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/antihax/optional"
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	rolloutTypeSchedule = "schedule"
+	rolloutTypeGradual  = "gradual"
+	rolloutTypeStepped  = "stepped"
+)
+
+// allUsersAudience is the audience filter used when a target doesn't set
+// audience_filters.
+var allUsersAudience = map[string]interface{}{
+	"operator": "and",
+	"filters":  []interface{}{map[string]interface{}{"type": "all"}},
+}
+
+var _ resource.ResourceWithConfigure = &featureTargetingResource{}
+var _ resource.ResourceWithImportState = &featureTargetingResource{}
+var _ resource.ResourceWithValidateConfig = &featureTargetingResource{}
+
+func newFeatureTargetingResource() resource.Resource {
+	return &featureTargetingResource{}
+}
+
+func (r *featureTargetingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_feature_targeting"
+}
+
+func (r *featureTargetingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Feature Targeting resource. Manages the targeting rules of a feature in a single environment, including scheduled rollouts.",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project id or key that the feature belongs to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"feature_id": schema.StringAttribute{
+				MarkdownDescription: "Feature id or key to target",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Environment id or key in which the feature is targeted",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Targeting status in the environment, one of `active` or `inactive`",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringOneOf("active", "inactive"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"targets": schema.ListNestedAttribute{
+				MarkdownDescription: "Targeting rules, evaluated in order",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Target name",
+							Optional:            true,
+						},
+						"audience_filters": schema.StringAttribute{
+							MarkdownDescription: "JSON encoded audience filters of the target. Targets all users when unset.",
+							Optional:            true,
+						},
+						"distribution": schema.MapAttribute{
+							MarkdownDescription: "Share of the audience served each variation, keyed by variation key. Percentages are between 0 and 1 and must add up to 1.",
+							Required:            true,
+							ElementType:         types.Float64Type,
+						},
+						"rollout": schema.SingleNestedAttribute{
+							MarkdownDescription: "Rollout of the target audience over time",
+							Optional:            true,
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Rollout type. `schedule` releases to the whole audience at `start_date`, `gradual` ramps up linearly to a single stage and `stepped` moves through each stage at its date.",
+									Required:            true,
+									Validators: []validator.String{
+										stringOneOf(rolloutTypeSchedule, rolloutTypeGradual, rolloutTypeStepped),
+									},
+								},
+								"start_date": schema.StringAttribute{
+									MarkdownDescription: "RFC 3339 date at which the rollout starts",
+									Required:            true,
+								},
+								"start_percentage": schema.Float64Attribute{
+									MarkdownDescription: "Share of the audience, between 0 and 1, targeted at `start_date`",
+									Optional:            true,
+								},
+								"stages": schema.ListNestedAttribute{
+									MarkdownDescription: "Rollout stages, ordered by date with non-decreasing percentages",
+									Optional:            true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"percentage": schema.Float64Attribute{
+												MarkdownDescription: "Share of the audience, between 0 and 1, targeted once the stage is reached",
+												Required:            true,
+											},
+											"date": schema.StringAttribute{
+												MarkdownDescription: "RFC 3339 date at which the stage percentage is fully applied",
+												Required:            true,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Feature ID and environment ID, separated by a slash",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+type featureTargetingResourceData struct {
+	Id            types.String                         `tfsdk:"id"`
+	ProjectId     types.String                         `tfsdk:"project_id"`
+	FeatureId     types.String                         `tfsdk:"feature_id"`
+	EnvironmentId types.String                         `tfsdk:"environment_id"`
+	Status        types.String                         `tfsdk:"status"`
+	Targets       []featureTargetingResourceDataTarget `tfsdk:"targets"`
+}
+
+type featureTargetingResourceDataTarget struct {
+	Name            types.String                         `tfsdk:"name"`
+	AudienceFilters types.String                         `tfsdk:"audience_filters"`
+	Distribution    map[string]types.Float64             `tfsdk:"distribution"`
+	Rollout         *featureTargetingResourceDataRollout `tfsdk:"rollout"`
+}
+
+type featureTargetingResourceDataRollout struct {
+	Type            types.String                               `tfsdk:"type"`
+	StartDate       types.String                               `tfsdk:"start_date"`
+	StartPercentage types.Float64                              `tfsdk:"start_percentage"`
+	Stages          []featureTargetingResourceDataRolloutStage `tfsdk:"stages"`
+}
+
+type featureTargetingResourceDataRolloutStage struct {
+	Percentage types.Float64 `tfsdk:"percentage"`
+	Date       types.String  `tfsdk:"date"`
+}
+
+// validate checks the targets at plan time: distributions must add up to 1,
+// rollout stages must match the rollout type, be ordered by date and never
+// decrease the targeted percentage.
+func (d featureTargetingResourceData) validate(diags *diag.Diagnostics) {
+	for i, target := range d.Targets {
+		targetPath := path.Root("targets").AtListIndex(i)
+
+		total := 0.0
+		known := true
+		for key, percentage := range target.Distribution {
+			if percentage.IsUnknown() || percentage.IsNull() {
+				known = false
+				continue
+			}
+			if percentage.ValueFloat64() < 0 || percentage.ValueFloat64() > 1 {
+				diags.AddAttributeError(
+					targetPath.AtName("distribution").AtMapKey(key),
+					"Invalid Distribution",
+					fmt.Sprintf("Percentage of variation %q must be between 0 and 1, got %v.", key, percentage.ValueFloat64()),
+				)
+			}
+			total += percentage.ValueFloat64()
+		}
+		if known && math.Abs(total-1) > 1e-9 {
+			diags.AddAttributeError(
+				targetPath.AtName("distribution"),
+				"Invalid Distribution",
+				fmt.Sprintf("Variation percentages must add up to 1, got %v.", total),
+			)
+		}
+
+		if target.Rollout != nil {
+			target.Rollout.validate(targetPath.AtName("rollout"), diags)
+		}
+	}
+}
+
+func (r featureTargetingResourceDataRollout) validate(attrPath path.Path, diags *diag.Diagnostics) {
+	if !r.Type.IsUnknown() {
+		switch {
+		case r.Type.ValueString() == rolloutTypeSchedule && len(r.Stages) > 0:
+			diags.AddAttributeError(attrPath.AtName("stages"), "Invalid Rollout", "A schedule rollout can't have stages.")
+		case r.Type.ValueString() == rolloutTypeGradual && len(r.Stages) != 1:
+			diags.AddAttributeError(attrPath.AtName("stages"), "Invalid Rollout", "A gradual rollout must have exactly one stage.")
+		case r.Type.ValueString() == rolloutTypeStepped && len(r.Stages) == 0:
+			diags.AddAttributeError(attrPath.AtName("stages"), "Invalid Rollout", "A stepped rollout must have at least one stage.")
+		}
+	}
+
+	previousPercentage, previousPercentageKnown := 0.0, true
+	if r.StartPercentage.IsUnknown() {
+		previousPercentageKnown = false
+	} else if !r.StartPercentage.IsNull() {
+		previousPercentage = r.StartPercentage.ValueFloat64()
+		if previousPercentage < 0 || previousPercentage > 1 {
+			diags.AddAttributeError(attrPath.AtName("start_percentage"), "Invalid Rollout", "start_percentage must be between 0 and 1.")
+		}
+	}
+
+	var previousDate *time.Time
+	if startDate, ok := parseRolloutDate(r.StartDate, attrPath.AtName("start_date"), diags); ok {
+		previousDate = &startDate
+	}
+
+	for j, stage := range r.Stages {
+		stagePath := attrPath.AtName("stages").AtListIndex(j)
+
+		if !stage.Percentage.IsUnknown() {
+			if stage.Percentage.ValueFloat64() < 0 || stage.Percentage.ValueFloat64() > 1 {
+				diags.AddAttributeError(stagePath.AtName("percentage"), "Invalid Rollout Stage", "Stage percentage must be between 0 and 1.")
+			}
+			if previousPercentageKnown && stage.Percentage.ValueFloat64() < previousPercentage {
+				diags.AddAttributeError(
+					stagePath.AtName("percentage"),
+					"Invalid Rollout Stage",
+					fmt.Sprintf("Stage percentage %v is lower than the previous percentage %v, rollouts can't decrease.", stage.Percentage.ValueFloat64(), previousPercentage),
+				)
+			}
+			previousPercentage, previousPercentageKnown = stage.Percentage.ValueFloat64(), true
+		} else {
+			previousPercentageKnown = false
+		}
+
+		date, ok := parseRolloutDate(stage.Date, stagePath.AtName("date"), diags)
+		if !ok {
+			previousDate = nil
+			continue
+		}
+		if previousDate != nil && !date.After(*previousDate) {
+			diags.AddAttributeError(
+				stagePath.AtName("date"),
+				"Invalid Rollout Stage",
+				fmt.Sprintf("Stage date %s must be after %s.", date.Format(time.RFC3339), previousDate.Format(time.RFC3339)),
+			)
+		}
+		previousDate = &date
+	}
+}
+
+// parseRolloutDate parses a known RFC 3339 date, reporting invalid dates at
+// attrPath.
+func parseRolloutDate(value types.String, attrPath path.Path, diags *diag.Diagnostics) (time.Time, bool) {
+	if value.IsUnknown() || value.IsNull() {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attrPath, "Invalid Date", fmt.Sprintf("Unable to parse %q as an RFC 3339 date: %s", value.ValueString(), err))
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// rolloutDateToTF formats a rollout date, keeping the prior value when it
+// refers to the same instant.
+func rolloutDateToTF(date time.Time, prior types.String) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if parsed, err := time.Parse(time.RFC3339, prior.ValueString()); err == nil && parsed.Equal(date) {
+			return prior
+		}
+	}
+	return types.StringValue(date.UTC().Format(time.RFC3339))
+}
+
+func (d featureTargetingResourceData) toSDK(diags *diag.Diagnostics) devcyclem.UpdateFeatureConfigDto {
+	ret := devcyclem.UpdateFeatureConfigDto{
+		Targets: []devcyclem.UpdateTargetDto{},
+	}
+	if !d.Status.IsUnknown() {
+		ret.Status = d.Status.ValueString()
+	}
+
+	for i, target := range d.Targets {
+		targetPath := path.Root("targets").AtListIndex(i)
+
+		var filters interface{} = allUsersAudience
+		if !target.AudienceFilters.IsNull() {
+			if err := json.Unmarshal([]byte(target.AudienceFilters.ValueString()), &filters); err != nil {
+				diags.AddAttributeError(targetPath.AtName("audience_filters"), "Invalid Audience Filters", fmt.Sprintf("Unable to parse audience filters as JSON: %s", err))
+				continue
+			}
+		}
+
+		sdkTarget := devcyclem.UpdateTargetDto{
+			Name:         target.Name.ValueString(),
+			Audience:     &devcyclem.AllOfUpdateTargetDtoAudience{Filters: filters},
+			Distribution: []devcyclem.TargetDistribution{},
+		}
+		for key, percentage := range target.Distribution {
+			sdkTarget.Distribution = append(sdkTarget.Distribution, devcyclem.TargetDistribution{
+				Variation:  key,
+				Percentage: percentage.ValueFloat64(),
+			})
+		}
+
+		if rollout := target.Rollout; rollout != nil {
+			startDate, _ := time.Parse(time.RFC3339, rollout.StartDate.ValueString())
+			sdkTarget.Rollout = &devcyclem.AllOfUpdateTargetDtoRollout{
+				Type_:           rollout.Type.ValueString(),
+				StartDate:       startDate,
+				StartPercentage: rollout.StartPercentage.ValueFloat64(),
+			}
+			// Gradual rollouts ramp up linearly to their stage, stepped
+			// rollouts jump to each stage percentage.
+			stageType := "discrete"
+			if rollout.Type.ValueString() == rolloutTypeGradual {
+				stageType = "linear"
+			}
+			for _, stage := range rollout.Stages {
+				date, _ := time.Parse(time.RFC3339, stage.Date.ValueString())
+				sdkTarget.Rollout.Stages = append(sdkTarget.Rollout.Stages, devcyclem.RolloutStage{
+					Percentage: stage.Percentage.ValueFloat64(),
+					Type_:      stageType,
+					Date:       date,
+				})
+			}
+		}
+
+		ret.Targets = append(ret.Targets, sdkTarget)
+	}
+	return ret
+}
+
+// fromSDK populates the data from a feature configuration. Distributions
+// reference variations by ID and are converted back to keys using the
+// variations of the feature.
+func (d *featureTargetingResourceData) fromSDK(config devcyclem.FeatureConfig, variations []devcyclem.Variation) {
+	variationKeys := make(map[string]string, len(variations))
+	for _, variation := range variations {
+		variationKeys[variation.Id] = variation.Key
+		variationKeys[variation.Key] = variation.Key
+	}
+
+	prior := d.Targets
+	d.Id = types.StringValue(config.Feature + "/" + config.Environment)
+	d.Status = types.StringValue(config.Status)
+	d.Targets = []featureTargetingResourceDataTarget{}
+	for i, target := range config.Targets {
+		var priorTarget featureTargetingResourceDataTarget
+		if i < len(prior) {
+			priorTarget = prior[i]
+		}
+
+		tfTarget := featureTargetingResourceDataTarget{
+			Name:            types.StringNull(),
+			AudienceFilters: types.StringNull(),
+			Distribution:    map[string]types.Float64{},
+		}
+		if target.Name != "" {
+			tfTarget.Name = types.StringValue(target.Name)
+		}
+		if target.Audience != nil && target.Audience.Filters != nil {
+			allUsers, _ := json.Marshal(allUsersAudience)
+			filters, _ := json.Marshal(target.Audience.Filters)
+			if priorTarget.AudienceFilters.IsNull() && jsonEqual(filters, allUsers) {
+				tfTarget.AudienceFilters = types.StringNull()
+			} else {
+				tfTarget.AudienceFilters = typedValueToTF("JSON", target.Audience.Filters, priorTarget.AudienceFilters)
+			}
+		}
+		for _, distribution := range target.Distribution {
+			key, ok := variationKeys[distribution.Variation]
+			if !ok {
+				key = distribution.Variation
+			}
+			tfTarget.Distribution[key] = types.Float64Value(distribution.Percentage)
+		}
+
+		if target.Rollout != nil {
+			var priorRollout featureTargetingResourceDataRollout
+			if priorTarget.Rollout != nil {
+				priorRollout = *priorTarget.Rollout
+			}
+			rollout := &featureTargetingResourceDataRollout{
+				Type:            types.StringValue(fmt.Sprintf("%v", target.Rollout.Type_)),
+				StartDate:       rolloutDateToTF(target.Rollout.StartDate, priorRollout.StartDate),
+				StartPercentage: types.Float64Null(),
+			}
+			if target.Rollout.StartPercentage != 0 || (!priorRollout.StartPercentage.IsNull() && !priorRollout.StartPercentage.IsUnknown()) {
+				rollout.StartPercentage = types.Float64Value(target.Rollout.StartPercentage)
+			}
+			for j, stage := range target.Rollout.Stages {
+				var priorDate types.String
+				if j < len(priorRollout.Stages) {
+					priorDate = priorRollout.Stages[j].Date
+				}
+				rollout.Stages = append(rollout.Stages, featureTargetingResourceDataRolloutStage{
+					Percentage: types.Float64Value(stage.Percentage),
+					Date:       rolloutDateToTF(stage.Date, priorDate),
+				})
+			}
+			tfTarget.Rollout = rollout
+		}
+
+		d.Targets = append(d.Targets, tfTarget)
+	}
+}
+
+func jsonEqual(a, b []byte) bool {
+	var av, bv interface{}
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+	an, _ := json.Marshal(av)
+	bn, _ := json.Marshal(bv)
+	return string(an) == string(bn)
+}
+
+type featureTargetingResource struct {
+	resourceBase
+}
+
+// read fetches the feature configuration of the environment along with the
+// feature variations. A missing feature or configuration is reported through
+// found rather than as an error.
+func (r *featureTargetingResource) read(ctx context.Context, data *featureTargetingResourceData, diags *diag.Diagnostics) (found bool) {
+	feature, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeaturesControllerFindOne(ctx, data.FeatureId.ValueString(), data.ProjectId.ValueString())
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+		return false
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, diags); ret {
+		return false
+	}
+
+	configs, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeatureConfigsControllerFindAll(ctx, data.FeatureId.ValueString(), data.ProjectId.ValueString(), &devcyclem.FeaturesApiFeatureConfigsControllerFindAllOpts{
+		Environment: optional.NewInterface(data.EnvironmentId.ValueString()),
+	})
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+		return false
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, diags); ret {
+		return false
+	}
+	if len(configs) == 0 {
+		return false
+	}
+
+	data.fromSDK(configs[0], feature.Variations)
+	return true
+}
+
+// write replaces the feature configuration of the environment. It is sent
+// without If-Match: configurations can only be read through the list
+// endpoint, whose etag does not identify the configuration being written, so
+// there is no etag to check the write against.
+func (r *featureTargetingResource) write(ctx context.Context, data *featureTargetingResourceData, diags *diag.Diagnostics) {
+	body := data.toSDK(diags)
+	if diags.HasError() {
+		return
+	}
+
+	_, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeatureConfigsControllerUpdate(ctx, body, data.EnvironmentId.ValueString(), data.FeatureId.ValueString(), data.ProjectId.ValueString())
+	if ret := handleDevCycleHTTP(err, httpResponse, diags); ret {
+		return
+	}
+
+	if found := r.read(ctx, data, diags); !found && !diags.HasError() {
+		diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: targeting of feature %q in environment %q was not found after write", data.FeatureId.ValueString(), data.EnvironmentId.ValueString()))
+	}
+}
+
+func (r *featureTargetingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data featureTargetingResourceData
+	// Whole lists or maps may still be unknown, in which case they are
+	// validated once known.
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		return
+	}
+	data.validate(&resp.Diagnostics)
+}
+
+func (r *featureTargetingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data featureTargetingResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.write(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *featureTargetingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data featureTargetingResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if found := r.read(ctx, &data, &resp.Diagnostics); !found {
+		if !resp.Diagnostics.HasError() {
+			resp.State.RemoveResource(ctx)
+		}
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *featureTargetingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data featureTargetingResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.write(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *featureTargetingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data featureTargetingResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Feature configurations exist for every environment and can't be
+	// deleted, so the targeting is turned off and cleared instead.
+	// Sent without If-Match for the same reason as write.
+	_, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeatureConfigsControllerUpdate(ctx, devcyclem.UpdateFeatureConfigDto{
+		Status:  "inactive",
+		Targets: []devcyclem.UpdateTargetDto{},
+	}, data.EnvironmentId.ValueString(), data.FeatureId.ValueString(), data.ProjectId.ValueString())
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *featureTargetingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := splitImportID(req.ID, 3)
+	if parts == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <project>/<feature>/<environment>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("feature_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[2])...)
+}
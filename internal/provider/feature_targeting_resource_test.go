@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFeatureTargetingResource(t *testing.T) {
+	testAccPreCheck(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFeatureTargetingResourceConfig(`
+    distribution = {
+      "variation-on` + randString + `" = 1
+    }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "status", "active"),
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.0.distribution.variation-on"+randString, "1"),
+					resource.TestCheckNoResourceAttr("devcycle_feature_targeting.test", "targets.0.rollout"),
+				),
+			},
+			{
+				Config: testAccFeatureTargetingResourceConfig(`
+    distribution = {
+      "variation-on` + randString + `" = 1
+    }
+    rollout = {
+      type             = "stepped"
+      start_date       = "2030-01-01T00:00:00Z"
+      start_percentage = 0.05
+      stages = [
+        { percentage = 0.25, date = "2030-01-03T00:00:00Z" },
+        { percentage = 1, date = "2030-01-07T00:00:00Z" },
+      ]
+    }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.0.rollout.type", "stepped"),
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.0.rollout.start_date", "2030-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.0.rollout.stages.#", "2"),
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.0.rollout.stages.1.percentage", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccFeatureTargetingResourceConfig(target string) string {
+	return `
+resource "devcycle_feature" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  name = "TerraformAccTestTargeting` + randString + `"
+  key = "terraform-acceptance-testing-targeting` + randString + `"
+  description = "Terraform acceptance testing"
+  type = "release"
+  variables = [
+	{
+	  name = "test-targeting-variable-name` + randString + `"
+	  description = "description"
+      key = "test-targeting-variable-key` + randString + `"
+      type = "Boolean"
+	}
+  ]
+  variations = [
+	{
+		key = "variation-on` + randString + `"
+		name = "Variation On"
+		variables = {
+			"test-targeting-variable-key` + randString + `" = "true"
+		}
+	}
+  ]
+}
+
+resource "devcycle_feature_targeting" "test" {
+  project_id     = devcycle_feature.test.project_id
+  feature_id     = devcycle_feature.test.key
+  environment_id = "development"
+  status         = "active"
+  targets = [{
+    name = "All Users"
+` + target + `
+  }]
+}
+`
+}
+
+func TestFeatureTargetingRolloutValidate(t *testing.T) {
+	stage := func(percentage float64, date string) featureTargetingResourceDataRolloutStage {
+		return featureTargetingResourceDataRolloutStage{
+			Percentage: types.Float64Value(percentage),
+			Date:       types.StringValue(date),
+		}
+	}
+	rollout := func(rolloutType string, stages ...featureTargetingResourceDataRolloutStage) featureTargetingResourceDataRollout {
+		return featureTargetingResourceDataRollout{
+			Type:            types.StringValue(rolloutType),
+			StartDate:       types.StringValue("2030-01-01T00:00:00Z"),
+			StartPercentage: types.Float64Value(0.05),
+			Stages:          stages,
+		}
+	}
+
+	cases := []struct {
+		name    string
+		rollout featureTargetingResourceDataRollout
+		valid   bool
+	}{
+		{"schedule", rollout(rolloutTypeSchedule), true},
+		{"schedule with stages", rollout(rolloutTypeSchedule, stage(1, "2030-01-02T00:00:00Z")), false},
+		{"gradual", rollout(rolloutTypeGradual, stage(1, "2030-01-02T00:00:00Z")), true},
+		{"gradual without stage", rollout(rolloutTypeGradual), false},
+		{"stepped", rollout(rolloutTypeStepped, stage(0.25, "2030-01-02T00:00:00Z"), stage(1, "2030-01-05T00:00:00+02:00")), true},
+		{"decreasing percentage", rollout(rolloutTypeStepped, stage(0.5, "2030-01-02T00:00:00Z"), stage(0.25, "2030-01-05T00:00:00Z")), false},
+		{"below start percentage", rollout(rolloutTypeStepped, stage(0.01, "2030-01-02T00:00:00Z")), false},
+		{"unordered dates", rollout(rolloutTypeStepped, stage(0.25, "2030-01-05T00:00:00Z"), stage(1, "2030-01-02T00:00:00Z")), false},
+		{"stage before start", rollout(rolloutTypeStepped, stage(1, "2029-12-31T00:00:00Z")), false},
+		{"invalid date", rollout(rolloutTypeStepped, stage(1, "tomorrow")), false},
+		{"percentage above 1", rollout(rolloutTypeStepped, stage(5, "2030-01-02T00:00:00Z")), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			featureTargetingResourceData{
+				Targets: []featureTargetingResourceDataTarget{{
+					Distribution: map[string]types.Float64{"on": types.Float64Value(1)},
+					Rollout:      &c.rollout,
+				}},
+			}.validate(&diags)
+			if c.valid && diags.HasError() {
+				t.Errorf("expected rollout to be valid, got: %v", diags)
+			}
+			if !c.valid && !diags.HasError() {
+				t.Error("expected rollout to be invalid")
+			}
+		})
+	}
+}
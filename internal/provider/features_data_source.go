@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &featuresDataSource{}
+
+func newFeaturesDataSource() datasource.DataSource {
+	return &featuresDataSource{}
+}
+
+func (d *featuresDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_features"
+}
+
+func (d *featuresDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Features data source. Lists the features of a project, optionally filtered.",
+
+		Attributes: listFilterAttributes(map[string]schema.Attribute{
+			"project_key": schema.StringAttribute{
+				MarkdownDescription: "Project key",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only return features of this type, e.g. `release` or `experiment`",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only return features with this status, one of `active`, `complete` or `archived`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(featureStatusActive, featureStatusComplete, featureStatusArchived),
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Only return features having all of these tags",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Project key",
+				Computed:            true,
+			},
+			"features": schema.ListNestedAttribute{
+				MarkdownDescription: "Features matching the filters, sorted by key unless `sort_by` is set",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Feature ID",
+						},
+						"key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Feature key",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Feature name",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Feature description",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Feature type",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Feature status",
+						},
+						"source": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Source of Feature creation",
+						},
+						"tags": schema.ListAttribute{
+							Computed:            true,
+							MarkdownDescription: "Feature tags",
+							ElementType:         types.StringType,
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Created at timestamp",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Updated at timestamp",
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+type featuresDataSourceData struct {
+	listFilterData
+	Id         types.String                    `tfsdk:"id"`
+	ProjectKey types.String                    `tfsdk:"project_key"`
+	Type       types.String                    `tfsdk:"type"`
+	Status     types.String                    `tfsdk:"status"`
+	Tags       []string                        `tfsdk:"tags"`
+	Features   []featuresDataSourceDataFeature `tfsdk:"features"`
+}
+
+type featuresDataSourceDataFeature struct {
+	Id          types.String `tfsdk:"id"`
+	Key         types.String `tfsdk:"key"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+	Status      types.String `tfsdk:"status"`
+	Source      types.String `tfsdk:"source"`
+	Tags        []string     `tfsdk:"tags"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+}
+
+type featuresDataSource struct {
+	dataSourceBase
+}
+
+func (d *featuresDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data featuresDataSourceData
+	if !d.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := data.query()
+	if data.Type.ValueString() != "" {
+		query.Set("type", data.Type.ValueString())
+	}
+	if data.Status.ValueString() != "" {
+		query.Set("status", data.Status.ValueString())
+	}
+	features, httpResponse, err := listMgmtPages[featureWithStatus](ctx, d.provider, featurePath(data.ProjectKey.ValueString(), ""), query)
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+
+	var matching []featureWithStatus
+	for _, feature := range features {
+		if feature.Status == "" {
+			feature.Status = featureStatusActive
+		}
+		// The type and status are filtered by the API too, they are checked
+		// again in case it ignores them.
+		if data.Type.ValueString() != "" && feature.Type_ != data.Type.ValueString() {
+			continue
+		}
+		if data.Status.ValueString() != "" && feature.Status != data.Status.ValueString() {
+			continue
+		}
+		if !containsAll(feature.Tags, data.Tags) {
+			continue
+		}
+		matching = append(matching, feature)
+	}
+	matching = filterAndSortList(matching, data.listFilterData, func(feature featureWithStatus) listItemFields {
+		return listItemFields{Key: feature.Key, Name: feature.Name, CreatedAt: feature.CreatedAt, UpdatedAt: feature.UpdatedAt}
+	}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.ProjectKey
+	data.Features = []featuresDataSourceDataFeature{}
+	for _, feature := range matching {
+		tags := feature.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		data.Features = append(data.Features, featuresDataSourceDataFeature{
+			Id:          types.StringValue(feature.Id),
+			Key:         types.StringValue(feature.Key),
+			Name:        types.StringValue(feature.Name),
+			Description: types.StringValue(feature.Description),
+			Type:        types.StringValue(feature.Type_),
+			Status:      types.StringValue(feature.Status),
+			Source:      types.StringValue(feature.Source),
+			Tags:        tags,
+			CreatedAt:   listTimeToTF(feature.CreatedAt),
+			UpdatedAt:   listTimeToTF(feature.UpdatedAt),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFeaturesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccFeaturesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devcycle_features.test", "id", "terraform-provider-testing"),
+					resource.TestCheckResourceAttrSet("data.devcycle_features.test", "features.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccFeaturesDataSourceConfig = `
+data "devcycle_features" "test" {
+  project_key = "terraform-provider-testing"
+}
+`
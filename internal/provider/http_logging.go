@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// httpLogSubsystem is the tflog subsystem of the DevCycle API requests.
+	// It logs at the provider log level, e.g. TF_LOG=DEBUG, unless its level
+	// is set with the DEVCYCLE_HTTP_LOG environment variable, e.g.
+	// DEVCYCLE_HTTP_LOG=TRACE to log the bodies without the provider TRACE
+	// logs, or DEVCYCLE_HTTP_LOG=OFF to turn it off.
+	httpLogSubsystem = "devcycle_http"
+	httpLogLevelEnv  = "DEVCYCLE_HTTP_LOG"
+
+	// httpLogBodyLimit is the number of bytes of request and response bodies
+	// logged.
+	httpLogBodyLimit = 4096
+
+	redacted = "[REDACTED]"
+)
+
+type httpAttemptContextKey struct{}
+
+// withHTTPAttempt returns a context logging the requests made with it as the
+// given attempt, counting from 1, of a retried request.
+func withHTTPAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, httpAttemptContextKey{}, attempt)
+}
+
+// httpLogRedactedHeaders are the headers whose values aren't logged.
+var httpLogRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// httpLogSecrets match the secrets in logged URLs and bodies, replaced by
+// their first submatch followed by redacted:
+//   - SDK keys, in server SDK URLs and environment responses;
+//   - tokens and client secrets of JSON bodies, e.g. OAuth token responses;
+//   - client secrets of form bodies, e.g. OAuth token requests.
+//
+// Values truncated by httpLogBodyLimit are still matched.
+var httpLogSecrets = []*regexp.Regexp{
+	regexp.MustCompile(`(dvc_(?:server|client|mobile)_)[\w\-]*`),
+	regexp.MustCompile(`("(?:access_token|refresh_token|id_token|client_secret)"\s*:\s*")(?:[^"\\]|\\.)*`),
+	regexp.MustCompile(`((?:^|[?&])(?:client_secret|access_token|refresh_token)=)[^&\s]*`),
+}
+
+// redactHTTPLog removes the secrets matched by httpLogSecrets from s.
+func redactHTTPLog(s string) string {
+	for _, secret := range httpLogSecrets {
+		s = secret.ReplaceAllString(s, "${1}"+redacted)
+	}
+	return s
+}
+
+// httpLoggingTransport logs the requests sent with base to the httpLogSubsystem
+// of the logger of their context: a DEBUG entry with the method, URL, retry
+// attempt, status and latency, and a TRACE entry with the headers and the
+// truncated bodies. Secrets are redacted. Requests whose context has no
+// logger, e.g. the background requests of the server SDK, aren't logged.
+type httpLoggingTransport struct {
+	base http.RoundTripper
+}
+
+func (t httpLoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	ctx := tflog.NewSubsystem(req.Context(), httpLogSubsystem, tflog.WithLevelFromEnv(httpLogLevelEnv))
+	if !tflog.SubsystemIsDebug(ctx, httpLogSubsystem) {
+		return base.RoundTrip(req)
+	}
+	trace := tflog.SubsystemIsTrace(ctx, httpLogSubsystem)
+
+	attempt, ok := req.Context().Value(httpAttemptContextKey{}).(int)
+	if !ok {
+		attempt = 1
+	}
+	fields := map[string]interface{}{
+		"http_method":  req.Method,
+		"http_url":     redactHTTPLog(req.URL.String()),
+		"http_attempt": attempt,
+	}
+
+	var requestBody string
+	if trace && req.Body != nil && req.Body != http.NoBody {
+		body, err := peekRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = body
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	fields["http_latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = redactHTTPLog(err.Error())
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "DevCycle API request failed", fields)
+		return resp, err
+	}
+	fields["http_status"] = resp.StatusCode
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "DevCycle API request", fields)
+
+	if trace {
+		fields["http_request_headers"] = redactHTTPHeaders(req.Header)
+		fields["http_request_body"] = requestBody
+		fields["http_response_headers"] = redactHTTPHeaders(resp.Header)
+		fields["http_response_body"] = peekResponseBody(resp)
+		tflog.SubsystemTrace(ctx, httpLogSubsystem, "DevCycle API request details", fields)
+	}
+	return resp, nil
+}
+
+// peekRequestBody returns the truncated, redacted body of req. A body that
+// can't be read again with GetBody is buffered and replaced.
+func peekRequestBody(req *http.Request) (string, error) {
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer body.Close()
+		return readHTTPLogBody(body), nil
+	}
+	contents, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(contents))
+	return readHTTPLogBody(bytes.NewReader(contents)), nil
+}
+
+// peekResponseBody returns the truncated, redacted body of resp, leaving the
+// body for the caller to read as if it wasn't peeked at.
+func peekResponseBody(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+	var peeked bytes.Buffer
+	body := readHTTPLogBody(io.TeeReader(resp.Body, &peeked))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(&peeked, resp.Body), resp.Body}
+	return body
+}
+
+// readHTTPLogBody reads up to httpLogBodyLimit bytes of body for logging.
+func readHTTPLogBody(body io.Reader) string {
+	contents, _ := io.ReadAll(io.LimitReader(body, httpLogBodyLimit+1))
+	truncated := len(contents) > httpLogBodyLimit
+	if truncated {
+		contents = contents[:httpLogBodyLimit]
+	}
+	ret := redactHTTPLog(string(contents))
+	if truncated {
+		ret += "... (truncated)"
+	}
+	return ret
+}
+
+func redactHTTPHeaders(header http.Header) map[string]string {
+	ret := make(map[string]string, len(header))
+	for name, values := range header {
+		ret[name] = redactHTTPLog(strings.Join(values, ", "))
+	}
+	for _, name := range httpLogRedactedHeaders {
+		if header.Get(name) != "" {
+			ret[http.CanonicalHeaderKey(name)] = redacted
+		}
+	}
+	return ret
+}
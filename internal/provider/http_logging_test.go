@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactHTTPLog(t *testing.T) {
+	for in, expected := range map[string]string{
+		"https://config-cdn.devcycle.com/config/v2/server/dvc_server_abc-123.json": "https://config-cdn.devcycle.com/config/v2/server/dvc_server_[REDACTED].json",
+		`{"access_token": "eyJ\"x", "expires_in": 86400}`:                          `{"access_token": "[REDACTED]", "expires_in": 86400}`,
+		`{"client_secret":"secr`:                                                   `{"client_secret":"[REDACTED]`,
+		"grant_type=client_credentials&client_id=id&client_secret=secret":          "grant_type=client_credentials&client_id=id&client_secret=[REDACTED]",
+		`{"key": "feature-key"}`:                                                   `{"key": "feature-key"}`,
+	} {
+		if got := redactHTTPLog(in); got != expected {
+			t.Errorf("expected %q to be redacted to %q, got %q", in, expected, got)
+		}
+	}
+}
+
+func TestHTTPLoggingTransport(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, `{"sdkKeys": {"server": [{"key": "dvc_server_secret"}]}, "name": "`+strings.Repeat("x", httpLogBodyLimit)+`"}`)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: retryTransport{base: httpLoggingTransport{}}}
+	get := func(ctx context.Context) string {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v1/projects", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "token")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	var output bytes.Buffer
+	body := get(tflogtest.RootLogger(context.Background(), &output))
+	if !strings.Contains(body, "dvc_server_secret") || !strings.HasSuffix(body, `"}`) {
+		t.Errorf("expected the whole response body to be read after logging, got %q", body)
+	}
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var statuses []interface{}
+	for _, entry := range entries {
+		if entry["@message"] == "DevCycle API request" {
+			statuses = append(statuses, entry["http_status"])
+			if entry["http_attempt"] != float64(len(statuses)) || entry["http_method"] != http.MethodGet {
+				t.Errorf("unexpected request entry %v", entry)
+			}
+		}
+		if entry["@message"] == "DevCycle API request details" && entry["http_status"] == float64(http.StatusOK) {
+			headers := entry["http_request_headers"].(map[string]interface{})
+			if headers["Authorization"] != redacted {
+				t.Errorf("expected the Authorization header to be redacted, got %v", headers)
+			}
+			responseBody := entry["http_response_body"].(string)
+			if strings.Contains(responseBody, "secret") || !strings.HasSuffix(responseBody, "(truncated)") {
+				t.Errorf("expected a redacted, truncated response body, got %q", responseBody)
+			}
+		}
+	}
+	if len(statuses) != 2 || statuses[0] != float64(http.StatusServiceUnavailable) || statuses[1] != float64(http.StatusOK) {
+		t.Errorf("expected both attempts to be logged, got %v", entries)
+	}
+
+	t.Setenv(httpLogLevelEnv, "OFF")
+	output.Reset()
+	attempts = 1
+	get(tflogtest.RootLogger(context.Background(), &output))
+	if output.Len() != 0 {
+		t.Errorf("expected %s to turn logging off, got %s", httpLogLevelEnv, output.String())
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestHTTPSettings(t *testing.T) {
+	get := func(settings httpSettings, url string) error {
+		resp, err := settings.client().Get(url)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	t.Run("proxy", func(t *testing.T) {
+		var proxied string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = r.URL.String()
+		}))
+		defer proxy.Close()
+
+		var diags diag.Diagnostics
+		settings := newHTTPSettings(providerData{ProxyURL: types.StringValue(proxy.URL)}, &diags)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		if err := get(settings, "http://api.devcycle.test/v1/projects"); err != nil {
+			t.Fatal(err)
+		}
+		if proxied != "http://api.devcycle.test/v1/projects" {
+			t.Errorf("expected the request to be sent through the proxy, got %q", proxied)
+		}
+	})
+
+	t.Run("CA certificate", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		var diags diag.Diagnostics
+		if err := get(newHTTPSettings(providerData{}, &diags), server.URL); err == nil {
+			t.Error("expected the certificate of the server not to be trusted")
+		}
+		settings := newHTTPSettings(providerData{CACertPEM: types.StringValue(string(caCert))}, &diags)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		if err := get(settings, server.URL); err != nil {
+			t.Errorf("expected the CA certificate to be trusted, got %s", err)
+		}
+
+		settings = newHTTPSettings(providerData{InsecureSkipVerify: types.BoolValue(true)}, &diags)
+		if len(diags) == 0 || diags[0].Severity() != diag.SeverityWarning {
+			t.Errorf("expected insecure_skip_verify to be warned about, got %v", diags)
+		}
+		if err := get(settings, server.URL); err != nil {
+			t.Errorf("expected the certificate not to be verified, got %s", err)
+		}
+	})
+
+	t.Run("request timeout", func(t *testing.T) {
+		var diags diag.Diagnostics
+		if settings := newHTTPSettings(providerData{}, &diags); settings.timeout != 0 || settings.client().Timeout != defaultRequestTimeout {
+			t.Errorf("expected the default timeout, got %s", settings.client().Timeout)
+		}
+		if settings := newHTTPSettings(providerData{RequestTimeout: types.StringValue("2m")}, &diags); settings.client().Timeout != 2*time.Minute {
+			t.Errorf("expected the configured timeout, got %s", settings.client().Timeout)
+		}
+		newHTTPSettings(providerData{RequestTimeout: types.StringValue("soon")}, &diags)
+		if !diags.HasError() || diags[0].Summary() != "Invalid Request Timeout" {
+			t.Errorf("expected an invalid timeout error, got %v", diags)
+		}
+	})
+
+	t.Run("invalid certificates", func(t *testing.T) {
+		var diags diag.Diagnostics
+		newHTTPSettings(providerData{
+			CACertPEM:  types.StringValue("not a certificate"),
+			ClientCert: types.StringValue("not a certificate"),
+			ClientKey:  types.StringValue("not a key"),
+		}, &diags)
+		if len(diags) != 2 || diags[0].Summary() != "Invalid CA Certificate" || diags[1].Summary() != "Invalid Client Certificate" {
+			t.Errorf("expected invalid certificate errors, got %v", diags)
+		}
+	})
+}
+
+func TestSetServerSDKTransport(t *testing.T) {
+	defaultTransport := http.DefaultTransport
+	serverSDKTransport.Lock()
+	installed, set, key, transport := serverSDKTransport.installed, serverSDKTransport.set, serverSDKTransport.key, serverSDKTransport.transport
+	serverSDKTransport.installed, serverSDKTransport.set, serverSDKTransport.transport = false, false, nil
+	serverSDKTransport.Unlock()
+	defer func() {
+		http.DefaultTransport = defaultTransport
+		serverSDKTransport.installed, serverSDKTransport.set = installed, set
+		serverSDKTransport.key, serverSDKTransport.transport = key, transport
+	}()
+
+	var sent []string
+	recording := func(name string) http.RoundTripper {
+		return mgmtTestTransport(func(req *http.Request) (*http.Response, error) {
+			sent = append(sent, name+" "+req.URL.Host)
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+		})
+	}
+	http.DefaultTransport = recording("default")
+
+	var diags diag.Diagnostics
+	setServerSDKTransport(httpSettings{
+		transport:    recording("first"),
+		transportKey: httpTransportKey{proxyURL: "http://proxy.example.com:3128"},
+	}, &diags)
+	// An alias with the same settings shares the transport, one with
+	// different settings gets a warning.
+	setServerSDKTransport(httpSettings{
+		transport:    recording("same"),
+		transportKey: httpTransportKey{proxyURL: "http://proxy.example.com:3128"},
+	}, &diags)
+	if len(diags) != 0 {
+		t.Errorf("expected the same settings to be accepted, got %v", diags)
+	}
+	setServerSDKTransport(httpSettings{
+		transport:    recording("conflicting"),
+		transportKey: httpTransportKey{insecureSkipVerify: true},
+	}, &diags)
+	if diags.HasError() || len(diags) != 1 || diags[0].Summary() != "Conflicting Provider Transport Settings" {
+		t.Errorf("expected a conflicting settings warning, got %v", diags)
+	}
+
+	for _, u := range []string{"https://config-cdn.devcycle.com/config", bucketingApiUrl + "/v1/variables", "https://example.com/"} {
+		req := httptest.NewRequest(http.MethodGet, u, nil)
+		if _, err := http.DefaultTransport.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := []string{"first config-cdn.devcycle.com", "first bucketing-api.devcycle.com", "default example.com"}
+	if strings.Join(sent, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected the requests to be sent with %v, got %v", expected, sent)
+	}
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &keyFunction{}
+
+func newKeyFunction() function.Function {
+	return &keyFunction{}
+}
+
+type keyFunction struct{}
+
+func (f *keyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "key"
+}
+
+func (f *keyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalize a name into a DevCycle key",
+		MarkdownDescription: "Converts a display name such as `New Checkout Flow` or `newCheckoutFlow` into the lowercase, kebab case key DevCycle expects, e.g. `new-checkout-flow`. Fails if the name doesn't contain any character allowed in a key or if the key is longer than 100 characters.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Name to build the key from",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *keyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = req.Arguments.Get(ctx, &name)
+	if resp.Error != nil {
+		return
+	}
+
+	key, err := normalizeKey(name)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, key)
+}
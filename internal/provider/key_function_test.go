@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestKeyFunction(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"spaces", "New Checkout Flow", "new-checkout-flow", true},
+		{"camel case", "newCheckoutFlow", "new-checkout-flow", true},
+		{"already a key", "new-checkout-flow", "new-checkout-flow", true},
+		{"punctuation", "  Checkout: v2 (beta)!  ", "checkout-v2-beta", true},
+		{"underscores and dots", "checkout_v2.1", "checkout_v2.1", true},
+		{"no allowed characters", "!!!", "", false},
+		{"too long", strings.Repeat("a", maxKeyLength+1), "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+			newKeyFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(c.input)}),
+			}, &resp)
+			if !c.valid {
+				if resp.Error == nil {
+					t.Errorf("expected %q to be rejected", c.input)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			if !resp.Result.Value().Equal(types.StringValue(c.expected)) {
+				t.Errorf("expected %q, got %s", c.expected, resp.Result.Value())
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listPageSize is the number of objects requested per page by the list data
+// sources.
+const listPageSize = 100
+
+const (
+	listSortByKey       = "key"
+	listSortByName      = "name"
+	listSortByCreatedAt = "created_at"
+	listSortByUpdatedAt = "updated_at"
+
+	listSortOrderAsc  = "asc"
+	listSortOrderDesc = "desc"
+)
+
+// listMgmtPages pages through a management API list endpoint until a page
+// shorter than listPageSize is returned.
+func listMgmtPages[T any](ctx context.Context, p *devcycleProvider, path string, query url.Values) ([]T, *http.Response, error) {
+	var items []T
+	for page := 1; ; page++ {
+		pageQuery := url.Values{}
+		for k, v := range query {
+			pageQuery[k] = v
+		}
+		pageQuery.Set("page", strconv.Itoa(page))
+		pageQuery.Set("perPage", strconv.Itoa(listPageSize))
+
+		var pageItems []T
+		httpResponse, err := p.doMgmtJSONRequest(ctx, http.MethodGet, path, pageQuery, nil, &pageItems)
+		if err != nil {
+			return nil, httpResponse, err
+		}
+		items = append(items, pageItems...)
+		if len(pageItems) < listPageSize {
+			return items, httpResponse, nil
+		}
+	}
+}
+
+// listFilterAttributes returns the filtering and sorting attributes shared by
+// the list data sources, merged with the attributes specific to each of them.
+func listFilterAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	ret := map[string]schema.Attribute{
+		"search": schema.StringAttribute{
+			MarkdownDescription: "Only return objects whose key or name contains this value",
+			Optional:            true,
+		},
+		"created_after": schema.StringAttribute{
+			MarkdownDescription: "Only return objects created at or after this RFC 3339 date",
+			Optional:            true,
+		},
+		"created_before": schema.StringAttribute{
+			MarkdownDescription: "Only return objects created before this RFC 3339 date",
+			Optional:            true,
+		},
+		"updated_after": schema.StringAttribute{
+			MarkdownDescription: "Only return objects last updated at or after this RFC 3339 date",
+			Optional:            true,
+		},
+		"updated_before": schema.StringAttribute{
+			MarkdownDescription: "Only return objects last updated before this RFC 3339 date",
+			Optional:            true,
+		},
+		"sort_by": schema.StringAttribute{
+			MarkdownDescription: "Attribute to sort by, one of `key` (the default), `name`, `created_at` or `updated_at`. Ties are sorted by key.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringOneOf(listSortByKey, listSortByName, listSortByCreatedAt, listSortByUpdatedAt),
+			},
+		},
+		"sort_order": schema.StringAttribute{
+			MarkdownDescription: "Sort order, `asc` (the default) or `desc`",
+			Optional:            true,
+			Validators: []validator.String{
+				stringOneOf(listSortOrderAsc, listSortOrderDesc),
+			},
+		},
+	}
+	for name, attribute := range attributes {
+		ret[name] = attribute
+	}
+	return ret
+}
+
+// listFilterData is the data of the attributes returned by
+// listFilterAttributes, embedded in the data of each list data source.
+type listFilterData struct {
+	Search        types.String `tfsdk:"search"`
+	CreatedAfter  types.String `tfsdk:"created_after"`
+	CreatedBefore types.String `tfsdk:"created_before"`
+	UpdatedAfter  types.String `tfsdk:"updated_after"`
+	UpdatedBefore types.String `tfsdk:"updated_before"`
+	SortBy        types.String `tfsdk:"sort_by"`
+	SortOrder     types.String `tfsdk:"sort_order"`
+}
+
+// listItemFields are the fields of a listed object that it can be filtered
+// and sorted by.
+type listItemFields struct {
+	Key       string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// query returns the query parameters of the filters the management API
+// applies itself.
+func (f listFilterData) query() url.Values {
+	query := url.Values{}
+	if f.Search.ValueString() != "" {
+		query.Set("search", f.Search.ValueString())
+	}
+	return query
+}
+
+// listTimeFilter is a parsed date filter, zero when unset.
+type listTimeFilter struct {
+	createdAfter, createdBefore, updatedAfter, updatedBefore time.Time
+}
+
+func (f listFilterData) timeFilter(diags *diag.Diagnostics) listTimeFilter {
+	parse := func(attribute string, value types.String) time.Time {
+		if value.ValueString() == "" {
+			return time.Time{}
+		}
+		parsed, err := time.Parse(time.RFC3339, value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(attribute),
+				"Invalid Date",
+				fmt.Sprintf("Unable to parse %q as an RFC 3339 date: %s", value.ValueString(), err),
+			)
+		}
+		return parsed
+	}
+	return listTimeFilter{
+		createdAfter:  parse("created_after", f.CreatedAfter),
+		createdBefore: parse("created_before", f.CreatedBefore),
+		updatedAfter:  parse("updated_after", f.UpdatedAfter),
+		updatedBefore: parse("updated_before", f.UpdatedBefore),
+	}
+}
+
+func (f listTimeFilter) matches(fields listItemFields) bool {
+	if !f.createdAfter.IsZero() && fields.CreatedAt.Before(f.createdAfter) {
+		return false
+	}
+	if !f.createdBefore.IsZero() && !fields.CreatedAt.Before(f.createdBefore) {
+		return false
+	}
+	if !f.updatedAfter.IsZero() && fields.UpdatedAt.Before(f.updatedAfter) {
+		return false
+	}
+	if !f.updatedBefore.IsZero() && !fields.UpdatedAt.Before(f.updatedBefore) {
+		return false
+	}
+	return true
+}
+
+// filterAndSortList applies the date filters and the sort order of f to
+// items. The output is sorted by key unless another order is requested, so it
+// doesn't depend on the order the management API returns objects in.
+func filterAndSortList[T any](items []T, f listFilterData, fieldsOf func(T) listItemFields, diags *diag.Diagnostics) []T {
+	timeFilter := f.timeFilter(diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	ret := []T{}
+	for _, item := range items {
+		if timeFilter.matches(fieldsOf(item)) {
+			ret = append(ret, item)
+		}
+	}
+
+	desc := f.SortOrder.ValueString() == listSortOrderDesc
+	sort.SliceStable(ret, func(i, j int) bool {
+		a, b := fieldsOf(ret[i]), fieldsOf(ret[j])
+		if desc {
+			a, b = b, a
+		}
+		switch f.SortBy.ValueString() {
+		case listSortByName:
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+		case listSortByCreatedAt:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		case listSortByUpdatedAt:
+			if !a.UpdatedAt.Equal(b.UpdatedAt) {
+				return a.UpdatedAt.Before(b.UpdatedAt)
+			}
+		}
+		return a.Key < b.Key
+	})
+	return ret
+}
+
+// listTimeToTF formats a timestamp of a listed object.
+func listTimeToTF(t time.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(t.Format(time.RFC3339))
+}
+
+// containsAll reports whether values contains every value of subset.
+func containsAll(values, subset []string) bool {
+	for _, want := range subset {
+		found := false
+		for _, value := range values {
+			if value == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
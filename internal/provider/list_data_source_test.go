@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFilterAndSortList(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	items := []listItemFields{
+		{Key: "c", Name: "Alpha", CreatedAt: day(3), UpdatedAt: day(4)},
+		{Key: "a", Name: "Charlie", CreatedAt: day(1), UpdatedAt: day(5)},
+		{Key: "b", Name: "Alpha", CreatedAt: day(2), UpdatedAt: day(3)},
+	}
+
+	cases := []struct {
+		name     string
+		filter   listFilterData
+		expected []string
+	}{
+		{"default", listFilterData{}, []string{"a", "b", "c"}},
+		{"descending", listFilterData{SortOrder: types.StringValue("desc")}, []string{"c", "b", "a"}},
+		{"by name with key ties", listFilterData{SortBy: types.StringValue("name")}, []string{"b", "c", "a"}},
+		{"by update date", listFilterData{SortBy: types.StringValue("updated_at")}, []string{"b", "c", "a"}},
+		{"created range", listFilterData{
+			CreatedAfter:  types.StringValue("2030-01-02T00:00:00Z"),
+			CreatedBefore: types.StringValue("2030-01-03T00:00:00Z"),
+		}, []string{"b"}},
+		{"updated after", listFilterData{UpdatedAfter: types.StringValue("2030-01-04T00:00:00+00:00")}, []string{"a", "c"}},
+		{"updated before", listFilterData{UpdatedBefore: types.StringValue("2030-01-04T00:00:00Z")}, []string{"b"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			filtered := filterAndSortList(items, c.filter, func(item listItemFields) listItemFields { return item }, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			keys := []string{}
+			for _, item := range filtered {
+				keys = append(keys, item.Key)
+			}
+			if !reflect.DeepEqual(keys, c.expected) {
+				t.Errorf("expected %v, got %v", c.expected, keys)
+			}
+		})
+	}
+
+	var diags diag.Diagnostics
+	filterAndSortList(items, listFilterData{CreatedAfter: types.StringValue("yesterday")}, func(item listItemFields) listItemFields { return item }, &diags)
+	if !diags.HasError() {
+		t.Error("expected an invalid date to be rejected")
+	}
+}
+
+func TestListDataSourceFilterConfig(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	newFeaturesDataSource().Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["project_key"] = tftypes.NewValue(tftypes.String, "project")
+	values["search"] = tftypes.NewValue(tftypes.String, "checkout")
+	values["tags"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "web")})
+
+	var data featuresDataSourceData
+	diags := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}.Get(ctx, &data)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := data.query().Get("search"); got != "checkout" {
+		t.Errorf("expected search to be sent to the API, got %q", got)
+	}
+	if !containsAll([]string{"api", "web"}, data.Tags) || containsAll([]string{"api"}, data.Tags) {
+		t.Errorf("expected features to be filtered by tags %v", data.Tags)
+	}
+}
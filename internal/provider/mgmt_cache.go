@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// mgmtPrefetchThreshold is the number of objects of a list endpoint read one
+// by one before the whole list is prefetched, see prefetchMgmtList.
+const mgmtPrefetchThreshold = 3
+
+type mgmtCacheBypassContextKey struct{}
+
+// withoutMgmtCache returns a context whose GET requests are sent to the
+// management API even when their response is cached, e.g. to poll for a
+// change made by a previous request.
+func withoutMgmtCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, mgmtCacheBypassContextKey{}, true)
+}
+
+type mgmtCacheETagContextKey struct{}
+
+// withMgmtETag returns a context whose GET requests aren't served from the
+// cached responses without an ETag, i.e. the objects of a prefetched list, so
+// that the object read can be written with If-Match. The response read
+// replaces the cached one.
+func withMgmtETag(ctx context.Context) context.Context {
+	return context.WithValue(ctx, mgmtCacheETagContextKey{}, true)
+}
+
+// mgmtCache is the read-through cache of the successful management API GET
+// responses of a provider instance, keyed by path and query, so that objects
+// read by many resources, e.g. the feature of each devcycle_variable, are only
+// fetched once per Terraform operation. Any write to a project drops the
+// responses of that project.
+type mgmtCache struct {
+	mu      sync.Mutex
+	entries map[string]mgmtCacheEntry
+	// projectIDs maps the project keys and IDs found in paths to the ID of
+	// their project, learned from the cached responses. Paths can reference a
+	// project by either, so a write by key must also drop the responses
+	// cached by ID.
+	projectIDs map[string]string
+	// generation is incremented by every write, so that a response read
+	// while a write was in flight isn't cached.
+	generation uint64
+
+	// reads counts the objects read one by one per list path, and prefetches
+	// holds the lists prefetched, or being prefetched, by prefetchMgmtList.
+	reads      map[string]int
+	prefetches map[string]*sync.Once
+}
+
+type mgmtCacheEntry struct {
+	status int
+	header http.Header
+	body   []byte
+	// project is the project path segment of the request, empty for paths
+	// outside of a project.
+	project string
+}
+
+func newMgmtCache() *mgmtCache {
+	return &mgmtCache{
+		entries:    map[string]mgmtCacheEntry{},
+		projectIDs: map[string]string{},
+		reads:      map[string]int{},
+		prefetches: map[string]*sync.Once{},
+	}
+}
+
+// mgmtProjectSegment returns the project key or ID of a path under
+// /v1/projects/{project}.
+func mgmtProjectSegment(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, "/v1/projects/")
+	if !ok || rest == "" {
+		return "", false
+	}
+	project, _, _ := strings.Cut(rest, "/")
+	return project, true
+}
+
+func mgmtCacheKey(u *url.URL) string {
+	return u.Path + "?" + u.RawQuery
+}
+
+// mgmtCacheTransport serves GET requests from cache, sending the others with
+// base and dropping the responses they may have changed.
+type mgmtCacheTransport struct {
+	base  http.RoundTripper
+	cache *mgmtCache
+}
+
+func (t mgmtCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		resp, err := t.base.RoundTrip(req)
+		// The write may have been applied even if it failed.
+		t.cache.invalidate(req.URL.Path)
+		return resp, err
+	}
+
+	key := mgmtCacheKey(req.URL)
+	if bypass, _ := req.Context().Value(mgmtCacheBypassContextKey{}).(bool); !bypass {
+		if resp := t.cache.get(key, req); resp != nil {
+			return resp, nil
+		}
+	}
+	generation := t.cache.currentGeneration()
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	project, _ := mgmtProjectSegment(req.URL.Path)
+	t.cache.put(key, generation, mgmtCacheEntry{
+		status:  resp.StatusCode,
+		header:  resp.Header.Clone(),
+		body:    body,
+		project: project,
+	}, true)
+	return resp, nil
+}
+
+func (c *mgmtCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// get returns the cached response of key to req, nil when it isn't cached or
+// has no ETag while the context of req requires one, see withMgmtETag.
+func (c *mgmtCache) get(key string, req *http.Request) *http.Response {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	if etag, _ := req.Context().Value(mgmtCacheETagContextKey{}).(bool); etag && entry.header.Get("ETag") == "" {
+		return nil
+	}
+	return &http.Response{
+		Status:        http.StatusText(entry.status),
+		StatusCode:    entry.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+		Request:       req,
+	}
+}
+
+// put caches entry unless a write happened since generation. An entry already
+// cached is only replaced when replace is set.
+func (c *mgmtCache) put(key string, generation uint64, entry mgmtCacheEntry, replace bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != generation {
+		return
+	}
+	if _, ok := c.entries[key]; ok && !replace {
+		return
+	}
+	c.entries[key] = entry
+	c.learnProjectID(entry)
+}
+
+// learnProjectID records the ID of the project of entry, read from the
+// project itself or from the _project of the objects of the project.
+func (c *mgmtCache) learnProjectID(entry mgmtCacheEntry) {
+	if entry.project == "" || c.projectIDs[entry.project] != "" {
+		return
+	}
+	type object struct {
+		Id      string `json:"_id"`
+		Project string `json:"_project"`
+	}
+	var single object
+	var list []object
+	if json.Unmarshal(entry.body, &single) != nil {
+		if json.Unmarshal(entry.body, &list) != nil || len(list) == 0 {
+			return
+		}
+		single = list[0]
+	}
+	id := single.Project
+	if id == "" && entry.project == single.Id {
+		id = single.Id
+	}
+	if id == "" {
+		return
+	}
+	c.projectIDs[entry.project] = id
+	c.projectIDs[id] = id
+}
+
+// invalidate drops the responses a write to path may have changed: those of
+// the project of path, including the project list, or every response when
+// path isn't in a project whose ID is known.
+func (c *mgmtCache) invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+
+	project, ok := mgmtProjectSegment(path)
+	id := c.projectIDs[project]
+	if (!ok && path != "/v1/projects") || (ok && id == "") {
+		c.entries = map[string]mgmtCacheEntry{}
+		return
+	}
+	for key, entry := range c.entries {
+		switch {
+		case entry.project == "":
+			if strings.HasPrefix(key, "/v1/projects?") {
+				delete(c.entries, key)
+			}
+		case !ok:
+			// A project was created, the objects of the others are unchanged.
+		case entry.project == project || c.projectIDs[entry.project] == id || c.projectIDs[entry.project] == "":
+			delete(c.entries, key)
+		}
+	}
+}
+
+// prefetchMgmtList caches the objects of a list endpoint, e.g. the variables
+// of a project, under the paths of their key and ID, so that refreshing many
+// resources reads a few pages instead of each object. It is called before
+// reading an object of the list and only prefetches once mgmtPrefetchThreshold
+// objects were read one by one, so that reading a few objects of a long list
+// doesn't page through it.
+//
+// The objects of a list response have no ETag, so the resources read from
+// them store none and must read the object again with withMgmtETag before
+// writing it to check it against concurrent changes, see
+// variableResource.writeEtag. Lists whose
+// objects differ from the ones read one by one, e.g. features without their
+// variables, must not be prefetched.
+func (p *devcycleProvider) prefetchMgmtList(ctx context.Context, listPath string) {
+	c := p.mgmtCache
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.reads[listPath]++
+	if c.reads[listPath] <= mgmtPrefetchThreshold {
+		c.mu.Unlock()
+		return
+	}
+	once, ok := c.prefetches[listPath]
+	if !ok {
+		once = &sync.Once{}
+		c.prefetches[listPath] = once
+	}
+	c.mu.Unlock()
+
+	once.Do(func() {
+		generation := c.currentGeneration()
+		items, _, err := listMgmtPages[json.RawMessage](ctx, p, listPath, nil)
+		if err != nil {
+			// The objects are read one by one instead.
+			tflog.Debug(ctx, "Unable to prefetch DevCycle objects", map[string]interface{}{"path": listPath, "error": err.Error()})
+			return
+		}
+		list, err := url.Parse(listPath)
+		if err != nil {
+			return
+		}
+		project, _ := mgmtProjectSegment(list.Path)
+		header := http.Header{"Content-Type": []string{"application/json"}}
+		for _, item := range items {
+			var object struct {
+				Id  string `json:"_id"`
+				Key string `json:"key"`
+			}
+			if json.Unmarshal(item, &object) != nil {
+				continue
+			}
+			entry := mgmtCacheEntry{status: http.StatusOK, header: header, body: item, project: project}
+			for _, name := range []string{object.Key, object.Id} {
+				if name != "" {
+					// Objects already read one by one keep their ETag.
+					c.put(mgmtCacheKey(&url.URL{Path: list.Path + "/" + name}), generation, entry, false)
+				}
+			}
+		}
+	})
+}
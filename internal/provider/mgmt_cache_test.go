@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type mgmtTestTransport func(*http.Request) (*http.Response, error)
+
+func (f mgmtTestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestMgmtCache(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		w.Header().Set("ETag", `"v1"`)
+		switch {
+		case r.Method != http.MethodGet:
+		case r.URL.Path == "/v1/projects/project-key/variables" || r.URL.Path == "/v1/projects/project-id/variables":
+			var items []string
+			for i := 0; i < 5; i++ {
+				items = append(items, fmt.Sprintf(`{"_id": "variable-id-%d", "key": "variable-%d", "_project": "project-id"}`, i, i))
+			}
+			_, _ = fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+		case strings.HasPrefix(r.URL.Path, "/v1/projects/project-"):
+			_, _ = fmt.Fprintf(w, `{"_id": "id", "key": "key", "_project": "project-id"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// Management API requests, e.g. the ones listing the prefetched objects,
+	// are sent to the test server.
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toServer := mgmtTestTransport(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme, req.URL.Host = serverURL.Scheme, serverURL.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	cache := newMgmtCache()
+	p := &devcycleProvider{
+		MgmtHTTPClient: &http.Client{Transport: mgmtCacheTransport{base: toServer, cache: cache}},
+		mgmtCache:      cache,
+	}
+	get := func(ctx context.Context, path string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := p.MgmtHTTPClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		return resp
+	}
+	write := func(path string) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPatch, server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := p.MgmtHTTPClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	count := func(path string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests["GET "+path]
+	}
+	ctx := context.Background()
+
+	t.Run("read through", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if resp := get(ctx, "/v1/projects/project-key/features/feature"); etagToTF(resp).ValueString() != `"v1"` {
+				t.Errorf("expected the ETag to be cached, got %v", resp.Header)
+			}
+		}
+		get(ctx, "/v1/projects/project-key/features/feature?page=2")
+		if n := count("/v1/projects/project-key/features/feature"); n != 2 {
+			t.Errorf("expected the responses to be cached by path and query, got %d requests", n)
+		}
+		get(withoutMgmtCache(ctx), "/v1/projects/project-key/features/feature")
+		if n := count("/v1/projects/project-key/features/feature"); n != 3 {
+			t.Errorf("expected the cache to be bypassed, got %d requests", n)
+		}
+		get(ctx, "/v1/projects/unknown")
+		get(ctx, "/v1/projects/unknown")
+		if n := count("/v1/projects/unknown"); n != 2 {
+			t.Errorf("expected errors not to be cached, got %d requests", n)
+		}
+	})
+
+	t.Run("invalidation", func(t *testing.T) {
+		get(ctx, "/v1/projects/project-id/features/feature")
+		get(ctx, "/v1/projects/project-other/features/feature")
+		cache.mu.Lock()
+		cache.projectIDs["project-other"] = "other-id"
+		cache.mu.Unlock()
+
+		// The project is written by key, the responses cached by ID are
+		// dropped too.
+		write("/v1/projects/project-key/variables/variable")
+		before := count("/v1/projects/project-id/features/feature")
+		get(ctx, "/v1/projects/project-id/features/feature")
+		if n := count("/v1/projects/project-id/features/feature"); n != before+1 {
+			t.Errorf("expected the write to drop the responses of the project, got %d requests", n-before)
+		}
+		before = count("/v1/projects/project-other/features/feature")
+		get(ctx, "/v1/projects/project-other/features/feature")
+		if n := count("/v1/projects/project-other/features/feature"); n != before {
+			t.Errorf("expected the responses of other projects to be kept, got %d requests", n-before)
+		}
+	})
+
+	t.Run("prefetch", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			path := variablePath("project-id", fmt.Sprintf("variable-%d", i))
+			p.prefetchMgmtList(ctx, variablePath("project-id", ""))
+			get(ctx, path)
+		}
+		if n := count("/v1/projects/project-id/variables"); n != 1 {
+			t.Errorf("expected the list to be prefetched once, got %d requests", n)
+		}
+		for i := 0; i < 5; i++ {
+			path := variablePath("project-id", fmt.Sprintf("variable-%d", i))
+			if n, expected := count(path), map[bool]int{true: 1, false: 0}[i < mgmtPrefetchThreshold]; n != expected {
+				t.Errorf("expected %s to be read %d times, got %d", path, expected, n)
+			}
+		}
+		if resp := get(ctx, variablePath("project-id", "variable-id-4")); etagToTF(resp).ValueString() != "" || count(variablePath("project-id", "variable-id-4")) != 0 {
+			t.Errorf("expected prefetched objects to be cached by ID without ETag, got %v", resp.Header)
+		}
+
+		// Reading an object to write it replaces its prefetched response
+		// with one with an ETag.
+		path := variablePath("project-id", "variable-4")
+		for i := 0; i < 2; i++ {
+			if resp := get(withMgmtETag(ctx), path); etagToTF(resp).ValueString() != `"v1"` {
+				t.Errorf("expected the object to be read with its ETag, got %v", resp.Header)
+			}
+		}
+		if n := count(path); n != 1 {
+			t.Errorf("expected the object to be read again once, got %d requests", n)
+		}
+	})
+}
@@ -1,13 +1,17 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 const mgmtAPIBaseURL = "https://api.devcycle.com"
@@ -16,11 +20,34 @@ type retryTransport struct {
 	base http.RoundTripper
 }
 
-func newMgmtHTTPClient() *http.Client {
-	return &http.Client{
-		Transport: retryTransport{base: http.DefaultTransport},
-		Timeout:   30 * time.Second,
+type ifMatchContextKey struct{}
+
+// withIfMatch returns a context sending etag as the If-Match header of the
+// management API request made with it, so that the API rejects the request
+// with 412 Precondition Failed when the resource changed since it was read.
+// Nothing is sent for an unknown or null etag, e.g. for resources read before
+// etags were tracked. The context must only be used for a single request, as
+// each write changes the etag of the resource.
+func withIfMatch(ctx context.Context, etag types.String) context.Context {
+	if etag.IsNull() || etag.IsUnknown() || etag.ValueString() == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ifMatchContextKey{}, etag.ValueString())
+}
+
+// etagToTF returns the ETag of a management API response, or null when the
+// response has none.
+func etagToTF(resp *http.Response) types.String {
+	if resp == nil || resp.Header.Get("ETag") == "" {
+		return types.StringNull()
 	}
+	return types.StringValue(resp.Header.Get("ETag"))
+}
+
+func newMgmtHTTPClient(settings httpSettings) *http.Client {
+	client := settings.client()
+	client.Transport = retryTransport{base: settings.transport}
+	return client
 }
 
 func (t retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -33,8 +60,18 @@ func (t retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	var err error
 
 	for attempt := 0; attempt < 3; attempt++ {
-		cloned := req.Clone(req.Context())
+		cloned := req.Clone(withHTTPAttempt(req.Context(), attempt+1))
 		cloned.Header = req.Header.Clone()
+		if etag, ok := req.Context().Value(ifMatchContextKey{}).(string); ok && cloned.Header.Get("If-Match") == "" {
+			cloned.Header.Set("If-Match", etag)
+		}
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			cloned.Body = body
+		}
 
 		resp, err = base.RoundTrip(cloned)
 		if !shouldRetryMgmtRequest(req, resp, err) || attempt == 2 {
@@ -96,7 +133,7 @@ func sleepWithContext(ctx context.Context, delay time.Duration) error {
 	}
 }
 
-func (p *provider) setMgmtRequestHeaders(req *http.Request) {
+func (p *devcycleProvider) setMgmtRequestHeaders(req *http.Request) {
 	req.Header.Set("Authorization", p.AccessToken)
 	req.Header.Set("dvc-referrer", "terraform")
 	terraformVersion := p.TerraformVersion
@@ -108,7 +145,11 @@ func (p *provider) setMgmtRequestHeaders(req *http.Request) {
 	req.Header.Set("User-Agent", "terraform-provider-devcycle")
 }
 
-func (p *provider) doMgmtRequest(ctx context.Context, method, path string, query url.Values, headers map[string]string) (*http.Response, error) {
+func (p *devcycleProvider) doMgmtRequest(ctx context.Context, method, path string, query url.Values, headers map[string]string) (*http.Response, error) {
+	return p.doMgmtRequestWithBody(ctx, method, path, query, headers, nil)
+}
+
+func (p *devcycleProvider) doMgmtRequestWithBody(ctx context.Context, method, path string, query url.Values, headers map[string]string, body []byte) (*http.Response, error) {
 	u, err := url.Parse(mgmtAPIBaseURL + path)
 	if err != nil {
 		return nil, err
@@ -117,10 +158,22 @@ func (p *provider) doMgmtRequest(ctx context.Context, method, path string, query
 		u.RawQuery = query.Encode()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
 	if err != nil {
 		return nil, err
 	}
+	if body != nil {
+		// Allow retryTransport to resend the body on a retried attempt.
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
 
 	p.setMgmtRequestHeaders(req)
 	for k, v := range headers {
@@ -129,8 +182,44 @@ func (p *provider) doMgmtRequest(ctx context.Context, method, path string, query
 
 	client := p.MgmtHTTPClient
 	if client == nil {
-		client = newMgmtHTTPClient()
+		client = newMgmtHTTPClient(httpSettings{})
 	}
 
 	return client.Do(req)
 }
+
+// doMgmtJSONRequest sends in (if non-nil) as the JSON request body and decodes
+// a successful response into out (if non-nil). It is used for management API
+// endpoints that are not covered by the generated go-mgmt-sdk client. Like the
+// generated client, a non-2xx status is reported as an error alongside the
+// response so callers can use handleDevCycleHTTP or inspect the status code.
+func (p *devcycleProvider) doMgmtJSONRequest(ctx context.Context, method, path string, query url.Values, in interface{}, out interface{}) (*http.Response, error) {
+	var body []byte
+	if in != nil {
+		var err error
+		body, err = json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := p.doMgmtRequestWithBody(ctx, method, path, query, nil, body)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, fmt.Errorf("%s: %s", resp.Status, string(respBody))
+	}
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
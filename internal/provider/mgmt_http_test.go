@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMgmtIfMatch(t *testing.T) {
+	var ifMatch string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifMatch = r.Header.Get("If-Match")
+		if ifMatch != "" && ifMatch != `"v2"` {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.Header().Set("ETag", `"v2"`)
+	}))
+	defer server.Close()
+
+	client := newMgmtHTTPClient(httpSettings{})
+	do := func(ctx context.Context) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	resp := do(withIfMatch(context.Background(), types.StringNull()))
+	if ifMatch != "" {
+		t.Errorf("expected no If-Match header for a null etag, got %q", ifMatch)
+	}
+	if etag := etagToTF(resp); etag.ValueString() != `"v2"` {
+		t.Errorf("expected etag to be captured, got %v", etag)
+	}
+
+	resp = do(withIfMatch(context.Background(), types.StringValue(`"v1"`)))
+	if ifMatch != `"v1"` {
+		t.Errorf("expected If-Match header to be sent, got %q", ifMatch)
+	}
+	var diags diag.Diagnostics
+	if !handleDevCycleHTTP(nil, resp, &diags) || diags[0].Summary() != "Resource Changed Outside Terraform" {
+		t.Errorf("expected a resource changed error, got %v", diags)
+	}
+}
@@ -2,57 +2,50 @@ package provider
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type projectDataSourceType struct{}
+var _ datasource.DataSourceWithConfigure = &projectDataSource{}
+
+func newProjectDataSource() datasource.DataSource {
+	return &projectDataSource{}
+}
+
+func (d *projectDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
 
-func (t projectDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
+func (d *projectDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "DevCycle Project data source.",
 
-		Attributes: map[string]tfsdk.Attribute{
-			"key": {
+		Attributes: map[string]schema.Attribute{
+			"key": schema.StringAttribute{
 				MarkdownDescription: "Project key, usually the lowercase, kebab case name of the project",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"id": {
+			"id": schema.StringAttribute{
 				MarkdownDescription: "Project Key",
 				Optional:            true,
-				Type:                types.StringType,
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
-				},
 			},
-			"name": {
+			"name": schema.StringAttribute{
 				MarkdownDescription: "Project name",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"description": {
+			"description": schema.StringAttribute{
 				MarkdownDescription: "Project description",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"organization": {
+			"organization": schema.StringAttribute{
 				MarkdownDescription: "Project org id",
 				Computed:            true,
-				Type:                types.StringType,
 			},
 		},
-	}, nil
-}
-
-func (t projectDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return projectDataSource{
-		provider: provider,
-	}, diags
+	}
 }
 
 type projectDataSourceData struct {
@@ -64,16 +57,12 @@ type projectDataSourceData struct {
 }
 
 type projectDataSource struct {
-	provider provider
+	dataSourceBase
 }
 
-func (d projectDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data projectDataSourceData
-	if !d.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !d.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.Config.Get(ctx, &data)
@@ -83,15 +72,15 @@ func (d projectDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceReq
 		return
 	}
 
-	project, httpResponse, err := d.provider.MgmtClient.ProjectsApi.ProjectsControllerFindOne(ctx, data.Key.Value)
+	project, httpResponse, err := d.provider.MgmtClient.ProjectsApi.ProjectsControllerFindOne(ctx, data.Key.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
-	data.Name = types.String{Value: project.Name}
-	data.Key = types.String{Value: project.Key}
-	data.Organization = types.String{Value: project.Organization}
-	data.Id = types.String{Value: project.Id}
-	data.Description = types.String{Value: project.Description}
+	data.Name = types.StringValue(project.Name)
+	data.Key = types.StringValue(project.Key)
+	data.Organization = types.StringValue(project.Organization)
+	data.Id = types.StringValue(project.Id)
+	data.Description = types.StringValue(project.Description)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -2,62 +2,72 @@ package provider
 
 import (
 	"context"
-	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-type projectResourceType struct{}
+var _ resource.ResourceWithConfigure = &projectResource{}
+var _ resource.ResourceWithImportState = &projectResource{}
+var _ resource.ResourceWithUpgradeState = &projectResource{}
+var _ resource.ResourceWithModifyPlan = &projectResource{}
+
+func newProjectResource() resource.Resource {
+	return &projectResource{}
+}
+
+func (r *projectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// Version 1 added etag.
+		Version: 1,
 
-func (t projectResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "DevCycle project resource. Allows for creation/modification of a project.",
 
-		Attributes: map[string]tfsdk.Attribute{
-			"description": {
+		Attributes: map[string]schema.Attribute{
+			"description": schema.StringAttribute{
 				MarkdownDescription: "Description of the project",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"name": {
+			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the project",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"key": {
+			"key": schema.StringAttribute{
 				MarkdownDescription: "Project key, usually the lowercase, kebab case name of the project",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"id": {
+			"etag": etagAttribute(),
+			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Project Key",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
-				Type: types.StringType,
 			},
-			"organization": {
+			"organization": schema.StringAttribute{
 				MarkdownDescription: "Organization that the project belongs to",
 				Computed:            true,
-				Type:                types.StringType,
 			},
 		},
-	}, nil
+	}
 }
 
-func (t projectResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return projectResource{
-		provider: provider,
-	}, diags
+func (r *projectResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(
+		withDefaults(map[string]interface{}{"etag": nil}),
+	)
 }
 
 type projectResourceData struct {
@@ -66,20 +76,21 @@ type projectResourceData struct {
 	Description  types.String `tfsdk:"description"`
 	Id           types.String `tfsdk:"id"`
 	Organization types.String `tfsdk:"organization"`
+	Etag         types.String `tfsdk:"etag"`
 }
 
 type projectResource struct {
-	provider provider
+	resourceBase
+}
+
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.warnRemoteKeyCollision(ctx, req, resp, projectKeyCheck)
 }
 
-func (r projectResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data projectResourceData
 
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 
@@ -91,32 +102,29 @@ func (r projectResource) Create(ctx context.Context, req tfsdk.CreateResourceReq
 	}
 
 	project, httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerCreate(ctx, devcyclem.CreateProjectDto{
-		Name:        data.Name.Value,
-		Key:         strings.ToLower(data.Key.Value),
-		Description: data.Description.Value,
+		Name:        data.Name.ValueString(),
+		Key:         strings.ToLower(data.Key.ValueString()),
+		Description: data.Description.ValueString(),
 	})
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Name = types.String{Value: project.Name}
-	data.Key = types.String{Value: project.Key}
-	data.Organization = types.String{Value: project.Organization}
-	data.Id = types.String{Value: project.Id}
+	data.Name = types.StringValue(project.Name)
+	data.Key = types.StringValue(project.Key)
+	data.Organization = types.StringValue(project.Organization)
+	data.Id = types.StringValue(project.Id)
+	data.Etag = etagToTF(httpResponse)
 
-	tflog.Trace(ctx, "Created a project with id %s", project.Id)
+	tflog.Trace(ctx, "Created a project", map[string]interface{}{"id": project.Id})
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r projectResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data projectResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.State.Get(ctx, &data)
@@ -126,27 +134,25 @@ func (r projectResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest
 		return
 	}
 
-	project, httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerFindOne(ctx, data.Key.Value)
+	project, httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerFindOne(ctx, data.Key.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Name = types.String{Value: project.Name}
-	data.Key = types.String{Value: project.Key}
-	data.Organization = types.String{Value: project.Organization}
-	data.Id = types.String{Value: project.Id}
+	data.Name = types.StringValue(project.Name)
+	data.Key = types.StringValue(project.Key)
+	data.Description = types.StringValue(project.Description)
+	data.Organization = types.StringValue(project.Organization)
+	data.Id = types.StringValue(project.Id)
+	data.Etag = etagToTF(httpResponse)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r projectResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data projectResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.Plan.Get(ctx, &data)
@@ -156,31 +162,34 @@ func (r projectResource) Update(ctx context.Context, req tfsdk.UpdateResourceReq
 		return
 	}
 
-	project, httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerUpdate(ctx, devcyclem.UpdateProjectDto{
-		Name:        data.Name.Value,
-		Key:         data.Key.Value,
-		Description: data.Description.Value,
-	}, data.Key.Value)
+	var etag types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("etag"), &etag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerUpdate(withIfMatch(ctx, etag), devcyclem.UpdateProjectDto{
+		Name:        data.Name.ValueString(),
+		Key:         data.Key.ValueString(),
+		Description: data.Description.ValueString(),
+	}, data.Key.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Name = types.String{Value: project.Name}
-	data.Key = types.String{Value: project.Key}
-	data.Organization = types.String{Value: project.Organization}
-	data.Id = types.String{Value: project.Id}
+	data.Name = types.StringValue(project.Name)
+	data.Key = types.StringValue(project.Key)
+	data.Organization = types.StringValue(project.Organization)
+	data.Id = types.StringValue(project.Id)
+	data.Etag = etagToTF(httpResponse)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r projectResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data projectResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.State.Get(ctx, &data)
@@ -190,16 +199,17 @@ func (r projectResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReq
 		return
 	}
 
-	httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerRemove(ctx, data.Key.Value)
+	httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerRemove(withIfMatch(ctx, data.Etag), data.Key.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
 
-	tflog.Trace(ctx, "Deleted project with id %s", data.Key.Value)
+	tflog.Trace(ctx, "Deleted a project", map[string]interface{}{"key": data.Key.ValueString()})
 
 	resp.State.RemoveResource(ctx)
 }
 
-func (r projectResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The project is read by key, which the API also accepts the project ID as.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), req.ID)...)
 }
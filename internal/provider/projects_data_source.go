@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &projectsDataSource{}
+
+func newProjectsDataSource() datasource.DataSource {
+	return &projectsDataSource{}
+}
+
+func (d *projectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+func (d *projectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Projects data source. Lists the projects of the organization, optionally filtered.",
+
+		Attributes: listFilterAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Always `projects`",
+				Computed:            true,
+			},
+			"projects": schema.ListNestedAttribute{
+				MarkdownDescription: "Projects matching the filters, sorted by key unless `sort_by` is set",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Project ID",
+						},
+						"key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Project key",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Project name",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Project description",
+						},
+						"organization": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Project org id",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Created at timestamp",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Updated at timestamp",
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+type projectsDataSourceData struct {
+	listFilterData
+	Id       types.String                    `tfsdk:"id"`
+	Projects []projectsDataSourceDataProject `tfsdk:"projects"`
+}
+
+type projectsDataSourceDataProject struct {
+	Id           types.String `tfsdk:"id"`
+	Key          types.String `tfsdk:"key"`
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	Organization types.String `tfsdk:"organization"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	UpdatedAt    types.String `tfsdk:"updated_at"`
+}
+
+type projectsDataSource struct {
+	dataSourceBase
+}
+
+func (d *projectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data projectsDataSourceData
+	if !d.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projects, httpResponse, err := listMgmtPages[devcyclem.Project](ctx, d.provider, "/v1/projects", data.query())
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+	projects = filterAndSortList(projects, data.listFilterData, func(project devcyclem.Project) listItemFields {
+		return listItemFields{Key: project.Key, Name: project.Name, CreatedAt: project.CreatedAt, UpdatedAt: project.UpdatedAt}
+	}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue("projects")
+	data.Projects = []projectsDataSourceDataProject{}
+	for _, project := range projects {
+		data.Projects = append(data.Projects, projectsDataSourceDataProject{
+			Id:           types.StringValue(project.Id),
+			Key:          types.StringValue(project.Key),
+			Name:         types.StringValue(project.Name),
+			Description:  types.StringValue(project.Description),
+			Organization: types.StringValue(project.Organization),
+			CreatedAt:    listTimeToTF(project.CreatedAt),
+			UpdatedAt:    listTimeToTF(project.UpdatedAt),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProjectsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccProjectsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devcycle_projects.test", "id", "projects"),
+					resource.TestCheckResourceAttrSet("data.devcycle_projects.test", "projects.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccProjectsDataSourceConfig = `
+data "devcycle_projects" "test" {
+  search = "terraform-provider-testing"
+}
+`
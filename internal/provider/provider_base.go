@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// providerBase is embedded in resources and data sources. It holds the
+// provider they were configured with and checks that the client they need can
+// be used, reporting why it can't otherwise.
+type providerBase struct {
+	provider *devcycleProvider
+}
+
+// resourceBase is the providerBase of resources.
+type resourceBase struct {
+	providerBase
+}
+
+func (b *resourceBase) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	b.provider = providerFromData(req.ProviderData, &resp.Diagnostics)
+}
+
+// dataSourceBase is the providerBase of data sources.
+type dataSourceBase struct {
+	providerBase
+}
+
+func (b *dataSourceBase) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	b.provider = providerFromData(req.ProviderData, &resp.Diagnostics)
+}
+
+// mgmtClientReady reports whether the management API client can be used,
+// without reporting anything. It is meant for best effort checks at plan time.
+func (b *providerBase) mgmtClientReady() bool {
+	return b.provider != nil && b.provider.mgmtClientError == ""
+}
+
+// requireMgmtClient reports whether the management API client can be used,
+// adding an error with the reason to diags when it can't.
+func (b *providerBase) requireMgmtClient(diags *diag.Diagnostics) bool {
+	if b.provider == nil {
+		addProviderNotConfiguredError(diags, providerNotConfigured)
+		return false
+	}
+	if b.provider.mgmtClientError != "" {
+		addProviderNotConfiguredError(diags, b.provider.mgmtClientError)
+		return false
+	}
+	return true
+}
+
+// requireServerClient is the server SDK counterpart of requireMgmtClient.
+func (b *providerBase) requireServerClient(diags *diag.Diagnostics) bool {
+	if b.provider == nil {
+		addProviderNotConfiguredError(diags, providerNotConfigured)
+		return false
+	}
+	if b.provider.serverClientError != "" {
+		addProviderNotConfiguredError(diags, b.provider.serverClientError)
+		return false
+	}
+	return true
+}
+
+func addProviderNotConfiguredError(diags *diag.Diagnostics, reason string) {
+	diags.AddError("Provider not configured", reason)
+}
@@ -1,13 +1,20 @@
 package provider
 
 import (
+	"context"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -15,9 +22,7 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"devcycle": func() (tfprotov6.ProviderServer, error) {
-		return tfsdk.NewProtocol6Server(New("testing")()), nil
-	},
+	"devcycle": providerserver.NewProtocol6WithError(New("testing")()),
 }
 var randString = ""
 
@@ -31,3 +36,228 @@ func testAccPreCheck(t *testing.T) {
 	t.Setenv("DEVCYCLE_ACCESS_TOKEN", os.Getenv("DEVCYCLE_ACCESS_TOKEN"))
 	t.Setenv("DEVCYCLE_SERVER_TOKEN", os.Getenv("DEVCYCLE_SERVER_TOKEN"))
 }
+
+// TestProviderSchema checks that the framework accepts the schemas of the
+// provider, its resources and data sources.
+func TestProviderSchema(t *testing.T) {
+	server, err := testAccProtoV6ProviderFactories["devcycle"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, diag := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", diag.Summary, diag.Detail)
+	}
+}
+
+func TestProviderConfigure(t *testing.T) {
+	t.Setenv("DEVCYCLE_CLIENT_ID", "")
+	t.Setenv("DEVCYCLE_CLIENT_SECRET", "")
+	t.Setenv("DEVCYCLE_ACCESS_TOKEN", "")
+	t.Setenv("DEVCYCLE_SERVER_TOKEN", "")
+
+	ctx := context.Background()
+	p := New("testing")().(*devcycleProvider)
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	null := tftypes.NewValue(tftypes.String, nil)
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	configWith := func(values map[string]tftypes.Value) tfsdk.Config {
+		attributes := map[string]tftypes.Value{}
+		for name, typ := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(typ, nil)
+		}
+		for name, value := range values {
+			attributes[name] = value
+		}
+		return tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, attributes),
+		}
+	}
+	config := func(clientId, clientSecret tftypes.Value) tfsdk.Config {
+		return configWith(map[string]tftypes.Value{"client_id": clientId, "client_secret": clientSecret})
+	}
+
+	t.Run("missing credentials", func(t *testing.T) {
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, provider.ConfigureRequest{Config: config(null, null)}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", resp.Diagnostics)
+		}
+		var diags diag.Diagnostics
+		base := providerBase{provider: p}
+		if base.requireMgmtClient(&diags) || diags[0].Detail() != mgmtCredentialsMissing {
+			t.Errorf("expected missing credentials to be reported, got %v", diags)
+		}
+		diags = nil
+		if base.requireServerClient(&diags) || diags[0].Detail() != serverSDKTokenMissing {
+			t.Errorf("expected missing server SDK token to be reported, got %v", diags)
+		}
+	})
+
+	t.Run("missing client secret", func(t *testing.T) {
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, provider.ConfigureRequest{Config: config(tftypes.NewValue(tftypes.String, "id"), null)}, &resp)
+		if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Missing Client Secret" {
+			t.Errorf("expected a missing client secret error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("access token", func(t *testing.T) {
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, provider.ConfigureRequest{Config: configWith(map[string]tftypes.Value{
+			"access_token": tftypes.NewValue(tftypes.String, "token"),
+		})}, &resp)
+		if resp.Diagnostics.HasError() || p.mgmtClientError != "" || p.AccessToken != "token" {
+			t.Errorf("expected the access token to be used, got %q, %v", p.AccessToken, resp.Diagnostics)
+		}
+
+		t.Setenv("DEVCYCLE_ACCESS_TOKEN", "env-token")
+		resp = provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: config(null, null)}, &resp)
+		if resp.Diagnostics.HasError() || p.mgmtClientError != "" || p.AccessToken != "env-token" {
+			t.Errorf("expected DEVCYCLE_ACCESS_TOKEN to be used, got %q, %v", p.AccessToken, resp.Diagnostics)
+		}
+	})
+
+	t.Run("access token file", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, provider.ConfigureRequest{Config: configWith(map[string]tftypes.Value{
+			"access_token_file": tftypes.NewValue(tftypes.String, tokenFile),
+		})}, &resp)
+		if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Unable to Read Access Token File" {
+			t.Errorf("expected a missing token file to be reported, got %v", resp.Diagnostics)
+		}
+
+		if err := os.WriteFile(tokenFile, []byte("token-1\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		resp = provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: configWith(map[string]tftypes.Value{
+			"access_token_file": tftypes.NewValue(tftypes.String, tokenFile),
+		})}, &resp)
+		if resp.Diagnostics.HasError() || p.mgmtClientError != "" || p.AccessToken != "token-1" {
+			t.Errorf("expected the token file to be read, got %q, %v", p.AccessToken, resp.Diagnostics)
+		}
+	})
+
+	t.Run("server SDK token", func(t *testing.T) {
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, provider.ConfigureRequest{Config: configWith(map[string]tftypes.Value{
+			"server_sdk_token":  tftypes.NewValue(tftypes.String, "invalid"),
+			"evaluation_events": tftypes.NewValue(tftypes.String, evaluationEventsDisabled),
+		})}, &resp)
+		if resp.Diagnostics.HasError() || p.serverSDKToken != "invalid" || p.serverClientOptions.events != evaluationEventsDisabled {
+			t.Errorf("expected the configured server SDK options to be used, got %q, %+v, %v", p.serverSDKToken, p.serverClientOptions, resp.Diagnostics)
+		}
+
+		// The client is created, and fails, when a data source uses it.
+		var diags diag.Diagnostics
+		b := evaluatedDataSourceBase{dataSourceBase{providerBase{provider: p}}}
+		if b.serverClient(ctx, evaluationSourceData{}, &diags) != nil || !strings.Contains(diags[0].Detail(), "check the server SDK token") {
+			t.Errorf("expected the server SDK client error to be reported, got %v", diags)
+		}
+
+		t.Setenv("DEVCYCLE_SERVER_TOKEN", "env-token")
+		resp = provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{Config: config(null, null)}, &resp)
+		if p.serverSDKToken != "env-token" || p.serverClientOptions.events != evaluationEventsDisabled {
+			t.Errorf("expected DEVCYCLE_SERVER_TOKEN to be used, got %q, %+v", p.serverSDKToken, p.serverClientOptions)
+		}
+	})
+
+	t.Run("unknown configuration", func(t *testing.T) {
+		var resp provider.ConfigureResponse
+		p.Configure(ctx, provider.ConfigureRequest{Config: config(unknown, unknown)}, &resp)
+		if resp.Diagnostics.HasError() || resp.Deferred != nil || p.mgmtClientError != providerConfigUnknown {
+			t.Errorf("expected the unknown configuration to be reported by resources, got %v", resp.Diagnostics)
+		}
+
+		resp = provider.ConfigureResponse{}
+		p.Configure(ctx, provider.ConfigureRequest{
+			Config:             config(unknown, unknown),
+			ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: true},
+		}, &resp)
+		if resp.Deferred == nil || resp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+			t.Errorf("expected configuration to be deferred, got %+v", resp.Deferred)
+		}
+	})
+}
+
+func TestProviderValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	p := New("testing")().(*devcycleProvider)
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	for name, tc := range map[string]struct {
+		values   map[string]string
+		expected []string
+	}{
+		"client credentials": {
+			values: map[string]string{"client_id": "id", "client_secret": "secret"},
+		},
+		"access token": {
+			values: map[string]string{"access_token": "token"},
+		},
+		"empty client ID": {
+			values: map[string]string{"access_token": "token", "client_id": ""},
+		},
+		"access token and file": {
+			values:   map[string]string{"access_token": "token", "access_token_file": "/token"},
+			expected: []string{"access_token_file"},
+		},
+		"access token and client credentials": {
+			values:   map[string]string{"access_token": "token", "client_id": "id", "client_secret": "secret"},
+			expected: []string{"access_token"},
+		},
+		"access token file and client secret": {
+			values:   map[string]string{"access_token_file": "/token", "client_secret": "secret"},
+			expected: []string{"access_token_file"},
+		},
+		"CA certificate and file": {
+			values:   map[string]string{"ca_cert_pem": "pem", "ca_cert_file": "/ca.pem"},
+			expected: []string{"ca_cert_file"},
+		},
+		"client certificate without key": {
+			values:   map[string]string{"client_cert": "pem"},
+			expected: []string{"client_cert"},
+		},
+		"client certificate and key": {
+			values: map[string]string{"client_cert": "pem", "client_key": "pem"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			attributes := map[string]tftypes.Value{}
+			for name, typ := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(typ, nil)
+			}
+			for name, value := range tc.values {
+				attributes[name] = tftypes.NewValue(tftypes.String, value)
+			}
+			var resp provider.ValidateConfigResponse
+			p.ValidateConfig(ctx, provider.ValidateConfigRequest{Config: tfsdk.Config{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(objectType, attributes),
+			}}, &resp)
+
+			var got []string
+			for _, d := range resp.Diagnostics {
+				if d, ok := d.(diag.DiagnosticWithPath); ok && d.Severity() == diag.SeverityError {
+					got = append(got, d.Path().String())
+				}
+			}
+			if len(got) != len(tc.expected) || (len(got) > 0 && got[0] != tc.expected[0]) {
+				t.Errorf("expected conflicts on %v, got %v", tc.expected, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// remoteKeyCheck describes how warnRemoteKeyCollision looks up the object a
+// resource is about to create.
+type remoteKeyCheck struct {
+	// kind names the object in the warning, e.g. "feature".
+	kind string
+	// projectScoped is set for the objects of a project, whose resources have
+	// a project_id.
+	projectScoped bool
+	// path returns the management API path of the object of a key. project is
+	// empty for projects.
+	path func(project, key string) string
+	// reused reports whether Create takes over the existing object instead of
+	// failing, e.g. a variable archived by a previous destroy.
+	reused func(body []byte) bool
+}
+
+var (
+	projectKeyCheck = remoteKeyCheck{
+		kind: "project",
+		path: func(_, key string) string { return "/v1/projects/" + url.PathEscape(key) },
+	}
+	environmentKeyCheck = remoteKeyCheck{
+		kind:          "environment",
+		projectScoped: true,
+		path: func(project, key string) string {
+			return fmt.Sprintf("/v1/projects/%s/environments/%s", url.PathEscape(project), url.PathEscape(key))
+		},
+	}
+	featureKeyCheck = remoteKeyCheck{
+		kind:          "feature",
+		projectScoped: true,
+		path:          featurePath,
+	}
+	variableKeyCheck = remoteKeyCheck{
+		kind:          "variable",
+		projectScoped: true,
+		path:          variablePath,
+		reused: func(body []byte) bool {
+			var variable struct {
+				Status string `json:"status"`
+			}
+			return json.Unmarshal(body, &variable) == nil && variable.Status == variableStatusArchived
+		},
+	}
+	customPropertyKeyCheck = remoteKeyCheck{
+		kind:          "custom property",
+		projectScoped: true,
+		path:          customPropertyPath,
+	}
+)
+
+// warnRemoteKeyCollision warns when validate_remote is set and the key a
+// resource is about to create, or rename to, is already used by another
+// object, so that the conflict is reported at plan time instead of failing
+// the apply halfway through. It is best effort: nothing is reported when the
+// object can't be looked up.
+func (b *resourceBase) warnRemoteKeyCollision(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, check remoteKeyCheck) {
+	if req.Plan.Raw.IsNull() || !b.mgmtClientReady() || !b.provider.validateRemote {
+		return
+	}
+
+	var key, project types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("key"), &key)...)
+	if check.projectScoped {
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("project_id"), &project)...)
+	}
+	if resp.Diagnostics.HasError() || !isSetString(key) || (check.projectScoped && !isSetString(project)) {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		// The project_id of the state may be the ID of the project configured
+		// by key, so only a changed key is checked.
+		var stateKey types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("key"), &stateKey)...)
+		if resp.Diagnostics.HasError() || stateKey.Equal(key) {
+			return
+		}
+	}
+
+	var existing json.RawMessage
+	httpResponse, err := b.provider.doMgmtJSONRequest(ctx, http.MethodGet, check.path(project.ValueString(), key.ValueString()), nil, nil, &existing)
+	if err != nil || httpResponse.StatusCode != http.StatusOK {
+		return
+	}
+	if check.reused != nil && check.reused(existing) {
+		return
+	}
+
+	importID := key.ValueString()
+	location := ""
+	if check.projectScoped {
+		importID = project.ValueString() + "/" + key.ValueString()
+		location = fmt.Sprintf(" in project %q", project.ValueString())
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("key"),
+		"Key Already Exists",
+		fmt.Sprintf("A %s with key %q already exists%s, so applying will fail with a conflict. "+
+			"Bring the existing %s under management by importing it with the ID %q, or use another key.",
+			check.kind, key.ValueString(), location, check.kind, importID),
+	)
+}
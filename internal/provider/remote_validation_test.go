@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// remoteValidationObject returns an object of the schema of r whose string
+// attributes are set from values and the others are null.
+func remoteValidationObject(t *testing.T, r resource.Resource, values map[string]string) tftypes.Value {
+	t.Helper()
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = tftypes.NewValue(attributeType, value)
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+func TestWarnRemoteKeyCollision(t *testing.T) {
+	var requests []string
+	p := &devcycleProvider{
+		validateRemote: true,
+		MgmtHTTPClient: &http.Client{Transport: mgmtTestTransport(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.URL.Path)
+			status, body := http.StatusNotFound, `{"message": "Not Found"}`
+			switch req.URL.Path {
+			case "/v1/projects/existing":
+				status, body = http.StatusOK, `{"_id": "project-id", "key": "existing"}`
+			case "/v1/projects/project/variables/existing":
+				status, body = http.StatusOK, `{"_id": "variable-id", "key": "existing", "status": "active"}`
+			case "/v1/projects/project/variables/archived":
+				status, body = http.StatusOK, `{"_id": "variable-id", "key": "archived", "status": "archived"}`
+			}
+			return &http.Response{
+				StatusCode: status,
+				Status:     http.StatusText(status),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Request:    req,
+			}, nil
+		})},
+	}
+	project := &projectResource{resourceBase{providerBase{provider: p}}}
+	variable := &variableResource{resourceBase{providerBase{provider: p}}}
+
+	modifyPlan := func(r resource.ResourceWithModifyPlan, state, plan map[string]string) *resource.ModifyPlanResponse {
+		t.Helper()
+		var schemaResp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+		req := resource.ModifyPlanRequest{
+			Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: remoteValidationObject(t, r, plan)},
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)},
+		}
+		if state != nil {
+			req.State.Raw = remoteValidationObject(t, r, state)
+		}
+		resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+		}
+		return resp
+	}
+
+	resp := modifyPlan(project, nil, map[string]string{"key": "existing"})
+	if resp.Diagnostics.WarningsCount() != 1 || !strings.Contains(resp.Diagnostics[0].Detail(), `the ID "existing"`) {
+		t.Errorf("expected a warning about the existing project, got %v", resp.Diagnostics)
+	}
+	if resp := modifyPlan(project, nil, map[string]string{"key": "new"}); resp.Diagnostics.WarningsCount() != 0 {
+		t.Errorf("expected no warning for a new key, got %v", resp.Diagnostics)
+	}
+	if resp := modifyPlan(project, map[string]string{"key": "old"}, map[string]string{"key": "existing"}); resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("expected a warning when renaming to an existing key, got %v", resp.Diagnostics)
+	}
+
+	requests = nil
+	if resp := modifyPlan(project, map[string]string{"key": "existing"}, map[string]string{"key": "existing"}); resp.Diagnostics.WarningsCount() != 0 || len(requests) != 0 {
+		t.Errorf("expected an unchanged key not to be looked up, got %v and requests %v", resp.Diagnostics, requests)
+	}
+
+	resp = modifyPlan(variable, nil, map[string]string{"key": "existing", "project_id": "project"})
+	if resp.Diagnostics.WarningsCount() != 1 || !strings.Contains(resp.Diagnostics[0].Detail(), `the ID "project/existing"`) {
+		t.Errorf("expected a warning about the existing variable, got %v", resp.Diagnostics)
+	}
+	if resp := modifyPlan(variable, nil, map[string]string{"key": "archived", "project_id": "project"}); resp.Diagnostics.WarningsCount() != 0 {
+		t.Errorf("expected no warning for an archived variable, got %v", resp.Diagnostics)
+	}
+
+	p.validateRemote = false
+	requests = nil
+	if resp := modifyPlan(project, nil, map[string]string{"key": "existing"}); resp.Diagnostics.WarningsCount() != 0 || len(requests) != 0 {
+		t.Errorf("expected nothing to be looked up without validate_remote, got %v and requests %v", resp.Diagnostics, requests)
+	}
+}
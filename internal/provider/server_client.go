@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	evaluationEventsEnabled  = "enabled"
+	evaluationEventsDisabled = "disabled"
+	evaluationEventsTagged   = "tagged"
+)
+
+// serverClientOptions are the provider options the server SDK clients are
+// created with.
+type serverClientOptions struct {
+	// events is how evaluation events are sent, one of the evaluationEvents
+	// constants.
+	events string
+	// workspace is the Terraform workspace the events are tagged with when
+	// events is evaluationEventsTagged.
+	workspace string
+	// timeout is the request_timeout of the provider, zero to use the
+	// default of the server SDK.
+	timeout time.Duration
+}
+
+// newServerClientOptions returns the options of the given evaluation_events
+// mode, disabling events when it isn't set, and request timeout.
+func newServerClientOptions(events string, timeout time.Duration) serverClientOptions {
+	if events == "" {
+		events = evaluationEventsDisabled
+	}
+	options := serverClientOptions{events: events, timeout: timeout}
+	if events == evaluationEventsTagged {
+		options.workspace = terraformWorkspace()
+	}
+	return options
+}
+
+func (o serverClientOptions) dvcOptions() *dvc_server.DVCOptions {
+	options := &dvc_server.DVCOptions{
+		EnableEdgeDB:    true,
+		BucketingAPIURI: bucketingApiUrl,
+		RequestTimeout:  o.timeout,
+	}
+	if o.events == evaluationEventsDisabled {
+		options.DisableAutomaticEventLogging = true
+		options.DisableCustomEventLogging = true
+	}
+	return options
+}
+
+// customData returns the client custom data the evaluation events are sent
+// with, nil when they aren't tagged.
+func (o serverClientOptions) customData() map[string]interface{} {
+	if o.events != evaluationEventsTagged {
+		return nil
+	}
+	return map[string]interface{}{
+		"terraform_workspace": o.workspace,
+	}
+}
+
+// terraformWorkspace returns the name of the Terraform workspace the provider
+// runs in. Terraform doesn't pass it to providers, so it is read the way
+// Terraform selects it: from TF_WORKSPACE, then from the environment file of
+// the data directory of the working directory.
+func terraformWorkspace() string {
+	if workspace := os.Getenv("TF_WORKSPACE"); workspace != "" {
+		return workspace
+	}
+	dataDir := os.Getenv("TF_DATA_DIR")
+	if dataDir == "" {
+		dataDir = ".terraform"
+	}
+	if contents, err := os.ReadFile(filepath.Join(dataDir, "environment")); err == nil {
+		if workspace := strings.TrimSpace(string(contents)); workspace != "" {
+			return workspace
+		}
+	}
+	return "default"
+}
+
+// serverClientRegistry holds the server SDK clients of the provider process.
+// Clients are created on first use and shared by every data source evaluating
+// with the same server SDK key and options, as each of them polls its
+// configuration and flushes events in the background until closed.
+type serverClientRegistry struct {
+	mu      sync.Mutex
+	clients map[serverClientKey]*dvc_server.DVCClient
+	closed  bool
+}
+
+type serverClientKey struct {
+	sdkKey  string
+	options serverClientOptions
+}
+
+// serverClients is the registry of the provider process, closed by
+// CloseServerClients when the provider server stops.
+var serverClients = &serverClientRegistry{}
+
+// get returns the client of the given server SDK key and options, creating it
+// on first use.
+func (r *serverClientRegistry) get(sdkKey string, options serverClientOptions) (*dvc_server.DVCClient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil, fmt.Errorf("the provider is stopping")
+	}
+	key := serverClientKey{sdkKey: sdkKey, options: options}
+	if client, ok := r.clients[key]; ok {
+		return client, nil
+	}
+	client, err := dvc_server.NewDVCClient(sdkKey, options.dvcOptions())
+	if err != nil {
+		// A client failing its initial configuration fetch is still
+		// polling, stop it. Closing waits for the client to initialize, so
+		// it isn't waited for.
+		if client != nil {
+			go func() { _ = client.Close() }()
+		}
+		return nil, err
+	}
+	if customData := options.customData(); customData != nil {
+		if err := client.SetClientCustomData(customData); err != nil {
+			go func() { _ = client.Close() }()
+			return nil, err
+		}
+	}
+	if r.clients == nil {
+		r.clients = map[serverClientKey]*dvc_server.DVCClient{}
+	}
+	r.clients[key] = client
+	return client, nil
+}
+
+// close closes every client, flushing their pending events. Clients can't be
+// created once the registry is closed.
+func (r *serverClientRegistry) close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+	var errs []error
+	for key, client := range r.clients {
+		if err := client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(r.clients, key)
+	}
+	return errors.Join(errs...)
+}
+
+// CloseServerClients closes the server SDK clients created by the provider,
+// flushing the evaluation events they haven't sent yet. It is called once the
+// provider server stops.
+func CloseServerClients() error {
+	return serverClients.close()
+}
+
+// evaluationSourceAttributes returns the attributes selecting the server SDK
+// key the evaluated variable data sources evaluate with, merged with the
+// attributes specific to each of them.
+func evaluationSourceAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	ret := map[string]schema.Attribute{
+		"server_sdk_token": schema.StringAttribute{
+			MarkdownDescription: "Server SDK token to evaluate the variable with instead of the `server_sdk_token` of the provider, e.g. to evaluate variables of several environments with one provider. Conflicts with `environment`.",
+			Optional:            true,
+			Sensitive:           true,
+		},
+		"environment": schema.SingleNestedAttribute{
+			MarkdownDescription: "Environment to evaluate the variable in instead of the one of the `server_sdk_token` of the provider. Its first server SDK key is read with the management API, so the provider must be configured with API credentials. Conflicts with `server_sdk_token`.",
+			Optional:            true,
+			Attributes: map[string]schema.Attribute{
+				"project_id": schema.StringAttribute{
+					MarkdownDescription: "Project id or key of the project the environment belongs to",
+					Required:            true,
+				},
+				"key": schema.StringAttribute{
+					MarkdownDescription: "Environment key or id",
+					Required:            true,
+				},
+			},
+		},
+	}
+	for name, attribute := range attributes {
+		ret[name] = attribute
+	}
+	return ret
+}
+
+// evaluationSourceData is the data of the attributes returned by
+// evaluationSourceAttributes, embedded in the data of each evaluated variable
+// data source.
+type evaluationSourceData struct {
+	ServerSDKToken types.String               `tfsdk:"server_sdk_token"`
+	Environment    *evaluationEnvironmentData `tfsdk:"environment"`
+}
+
+type evaluationEnvironmentData struct {
+	ProjectId types.String `tfsdk:"project_id"`
+	Key       types.String `tfsdk:"key"`
+}
+
+// evaluatedDataSourceBase is the dataSourceBase of the evaluated variable data
+// sources.
+type evaluatedDataSourceBase struct {
+	dataSourceBase
+}
+
+func (b *evaluatedDataSourceBase) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var token types.String
+	var environment types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("server_sdk_token"), &token)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("environment"), &environment)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !token.IsNull() && !environment.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Conflicting Server SDK Token",
+			"environment can't be set along with server_sdk_token, set only one of them.",
+		)
+	}
+}
+
+// serverClient returns the server SDK client to evaluate with, selected by
+// source, adding an error to diags when it can't be used.
+func (b *evaluatedDataSourceBase) serverClient(ctx context.Context, source evaluationSourceData, diags *diag.Diagnostics) *dvc_server.DVCClient {
+	sdkKey := source.ServerSDKToken.ValueString()
+	switch {
+	case sdkKey != "":
+		if b.provider == nil {
+			addProviderNotConfiguredError(diags, providerNotConfigured)
+			return nil
+		}
+	case source.Environment != nil:
+		if !b.requireMgmtClient(diags) {
+			return nil
+		}
+		sdkKey = b.environmentServerSDKKey(ctx, *source.Environment, diags)
+		if diags.HasError() {
+			return nil
+		}
+	default:
+		if !b.requireServerClient(diags) {
+			return nil
+		}
+		sdkKey = b.provider.serverSDKToken
+	}
+
+	client, err := serverClients.get(sdkKey, b.provider.serverClientOptions)
+	if err != nil {
+		diags.AddError(
+			"Unable to Create Server SDK Client",
+			fmt.Sprintf("Unable to create the DevCycle server SDK client, check the server SDK token: %s", err),
+		)
+		return nil
+	}
+	return client
+}
+
+// environmentServerSDKKey reads the first server SDK key of an environment.
+func (b *evaluatedDataSourceBase) environmentServerSDKKey(ctx context.Context, environment evaluationEnvironmentData, diags *diag.Diagnostics) string {
+	env, httpResponse, err := b.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerFindOne(ctx, environment.Key.ValueString(), environment.ProjectId.ValueString())
+	if ret := handleDevCycleHTTP(err, httpResponse, diags); ret {
+		return ""
+	}
+	if env.SdkKeys == nil || len(env.SdkKeys.Server) == 0 {
+		diags.AddAttributeError(
+			path.Root("environment"),
+			"Missing Server SDK Key",
+			fmt.Sprintf("Environment %q of project %q has no server SDK key.", environment.Key.ValueString(), environment.ProjectId.ValueString()),
+		)
+		return ""
+	}
+	return env.SdkKeys.Server[0].Key
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEvaluationSourceValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	d := &evaluatedBooleanVariableDataSource{}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	environmentType := objectType.AttributeTypes["environment"].(tftypes.Object)
+
+	validate := func(token, environment tftypes.Value) diag.Diagnostics {
+		attributes := map[string]tftypes.Value{}
+		for name, typ := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(typ, nil)
+		}
+		attributes["server_sdk_token"] = token
+		attributes["environment"] = environment
+		var resp datasource.ValidateConfigResponse
+		d.ValidateConfig(ctx, datasource.ValidateConfigRequest{Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, attributes),
+		}}, &resp)
+		return resp.Diagnostics
+	}
+	token := tftypes.NewValue(tftypes.String, "dvc_server_token")
+	environment := tftypes.NewValue(environmentType, map[string]tftypes.Value{
+		"project_id": tftypes.NewValue(tftypes.String, "project"),
+		"key":        tftypes.NewValue(tftypes.String, "production"),
+	})
+
+	if diags := validate(token, tftypes.NewValue(environmentType, nil)); diags.HasError() {
+		t.Errorf("unexpected error: %v", diags)
+	}
+	if diags := validate(tftypes.NewValue(tftypes.String, nil), environment); diags.HasError() {
+		t.Errorf("unexpected error: %v", diags)
+	}
+	if diags := validate(token, environment); !diags.HasError() || diags[0].Summary() != "Conflicting Server SDK Token" {
+		t.Errorf("expected a conflict error, got %v", diags)
+	}
+}
+
+func TestEvaluationSourceServerClient(t *testing.T) {
+	ctx := context.Background()
+	p := &devcycleProvider{
+		mgmtClientError:   mgmtCredentialsMissing,
+		serverClientError: serverSDKTokenMissing,
+	}
+	b := evaluatedDataSourceBase{dataSourceBase{providerBase{provider: p}}}
+
+	var diags diag.Diagnostics
+	if b.serverClient(ctx, evaluationSourceData{}, &diags) != nil || diags[0].Detail() != serverSDKTokenMissing {
+		t.Errorf("expected the missing provider token to be reported, got %v", diags)
+	}
+
+	diags = nil
+	source := evaluationSourceData{
+		Environment: &evaluationEnvironmentData{ProjectId: types.StringValue("project"), Key: types.StringValue("production")},
+	}
+	if b.serverClient(ctx, source, &diags) != nil || diags[0].Detail() != mgmtCredentialsMissing {
+		t.Errorf("expected missing API credentials to be reported, got %v", diags)
+	}
+
+	diags = nil
+	source = evaluationSourceData{ServerSDKToken: types.StringValue("invalid")}
+	if b.serverClient(ctx, source, &diags) != nil || diags[0].Summary() != "Unable to Create Server SDK Client" {
+		t.Errorf("expected the invalid token to be reported, got %v", diags)
+	}
+}
+
+func TestServerClientRegistry(t *testing.T) {
+	r := &serverClientRegistry{}
+	if _, err := r.get("invalid", serverClientOptions{}); err == nil {
+		t.Error("expected an invalid server SDK key to be rejected")
+	}
+	if len(r.clients) != 0 {
+		t.Errorf("expected failed clients not to be kept, got %v", r.clients)
+	}
+
+	if err := r.close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.get("dvc_server_token", serverClientOptions{}); err == nil || err.Error() != "the provider is stopping" {
+		t.Errorf("expected clients not to be created once closed, got %v", err)
+	}
+}
+
+func TestServerClientOptions(t *testing.T) {
+	if options := (serverClientOptions{events: evaluationEventsEnabled}).dvcOptions(); options.DisableAutomaticEventLogging || options.DisableCustomEventLogging {
+		t.Errorf("expected events to be enabled, got %+v", options)
+	}
+	if options := (serverClientOptions{events: evaluationEventsDisabled}).dvcOptions(); !options.DisableAutomaticEventLogging || !options.DisableCustomEventLogging {
+		t.Errorf("expected events to be disabled, got %+v", options)
+	}
+	if options := newServerClientOptions("", 0); options.events != evaluationEventsDisabled || options.customData() != nil {
+		t.Errorf("expected events to be disabled by default, got %+v", options)
+	}
+
+	t.Setenv("TF_WORKSPACE", "staging")
+	options := newServerClientOptions(evaluationEventsTagged, 0)
+	if dvcOptions := options.dvcOptions(); dvcOptions.DisableAutomaticEventLogging || dvcOptions.DisableCustomEventLogging {
+		t.Errorf("expected tagged events to be enabled, got %+v", dvcOptions)
+	}
+	if workspace := options.customData()["terraform_workspace"]; workspace != "staging" {
+		t.Errorf("expected events to be tagged with the workspace, got %v", workspace)
+	}
+}
+
+func TestTerraformWorkspace(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("TF_WORKSPACE", "")
+	t.Setenv("TF_DATA_DIR", dataDir)
+	if workspace := terraformWorkspace(); workspace != "default" {
+		t.Errorf("expected the default workspace, got %q", workspace)
+	}
+
+	if err := os.WriteFile(filepath.Join(dataDir, "environment"), []byte("production"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if workspace := terraformWorkspace(); workspace != "production" {
+		t.Errorf("expected the selected workspace, got %q", workspace)
+	}
+
+	t.Setenv("TF_WORKSPACE", "staging")
+	if workspace := terraformWorkspace(); workspace != "staging" {
+		t.Errorf("expected TF_WORKSPACE to take precedence, got %q", workspace)
+	}
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// stateUpgrader upgrades the raw JSON state of a resource from one schema
+// version to the next.
+type stateUpgrader func(state map[string]interface{}) (map[string]interface{}, error)
+
+// stateUpgraders returns the UpgradeState map of a resource from the chain of
+// upgraders of its schema versions. The upgrader at index n upgrades state
+// from schema version n to n+1, so there must be as many upgraders as the
+// schema Version of the resource. State of each prior version is upgraded by
+// running the rest of the chain.
+func stateUpgraders(chain ...stateUpgrader) map[int64]resource.StateUpgrader {
+	ret := make(map[int64]resource.StateUpgrader, len(chain))
+	for version := range chain {
+		upgraders := chain[version:]
+		ret[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil || req.RawState.JSON == nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Unable to upgrade the state from schema version %d: the state isn't JSON encoded.", version),
+					)
+					return
+				}
+				upgraded, err := upgradeState(req.RawState.JSON, upgraders)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Unable to upgrade the state from schema version %d: %s", version, err),
+					)
+					return
+				}
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+			},
+		}
+	}
+	return ret
+}
+
+func upgradeState(raw []byte, upgraders []stateUpgrader) ([]byte, error) {
+	var state map[string]interface{}
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, err
+	}
+	for _, upgrader := range upgraders {
+		var err error
+		if state, err = upgrader(state); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(state)
+}
+
+// withDefaults returns an upgrader that sets the attributes added in the next
+// schema version to their default value. Attributes already set are kept, as
+// state written by later releases of the same schema version may have them.
+func withDefaults(defaults map[string]interface{}) stateUpgrader {
+	return func(state map[string]interface{}) (map[string]interface{}, error) {
+		for name, value := range defaults {
+			if state[name] == nil {
+				state[name] = value
+			}
+		}
+		return state, nil
+	}
+}
+
+// upgradeFeatureStateV0 moves tags, variables and variations from lists to
+// sets. The JSON encoding of both is the same, but sets can't hold duplicates
+// so repeated tags and keys are dropped, keeping the first one. It also sets
+// the attributes added along with it, features from before status was managed
+// being active.
+func upgradeFeatureStateV0(state map[string]interface{}) (map[string]interface{}, error) {
+	state, _ = withDefaults(map[string]interface{}{
+		"status":           featureStatusActive,
+		"static_variation": nil,
+		"on_destroy":       nil,
+		"settings":         nil,
+		"sdk_visibility":   nil,
+		"etag":             nil,
+	})(state)
+	if tags, ok := state["tags"].([]interface{}); ok {
+		state["tags"] = uniqueBy(tags, func(v interface{}) interface{} { return v })
+	}
+	for _, attribute := range []string{"variables", "variations"} {
+		if elems, ok := state[attribute].([]interface{}); ok {
+			state[attribute] = uniqueBy(elems, func(v interface{}) interface{} {
+				if obj, ok := v.(map[string]interface{}); ok {
+					return obj["key"]
+				}
+				return v
+			})
+		}
+	}
+	return state, nil
+}
+
+func uniqueBy(elems []interface{}, key func(interface{}) interface{}) []interface{} {
+	seen := make(map[string]bool, len(elems))
+	ret := make([]interface{}, 0, len(elems))
+	for _, elem := range elems {
+		k, err := json.Marshal(key(elem))
+		if err != nil || seen[string(k)] {
+			continue
+		}
+		seen[string(k)] = true
+		ret = append(ret, elem)
+	}
+	return ret
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestResourceStateUpgraders(t *testing.T) {
+	ctx := context.Background()
+	p := New("testing")()
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "devcycle"}, &metadata)
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+		var upgraders map[int64]resource.StateUpgrader
+		if withUpgradeState, ok := r.(resource.ResourceWithUpgradeState); ok {
+			upgraders = withUpgradeState.UpgradeState(ctx)
+		}
+		if got := int64(len(upgraders)); got != schemaResp.Schema.Version {
+			t.Errorf("%s has schema version %d but %d state upgraders", metadata.TypeName, schemaResp.Schema.Version, got)
+		}
+		for version := int64(0); version < schemaResp.Schema.Version; version++ {
+			if _, ok := upgraders[version]; !ok {
+				t.Errorf("%s has no state upgrader from version %d", metadata.TypeName, version)
+			}
+		}
+	}
+}
+
+func TestUpgradeFeatureStateV0(t *testing.T) {
+	state := map[string]interface{}{
+		"key":  "feature",
+		"tags": []interface{}{"a", "b", "a"},
+		"variables": []interface{}{
+			map[string]interface{}{"key": "one", "name": "One"},
+			map[string]interface{}{"key": "two", "name": "Two"},
+			map[string]interface{}{"key": "one", "name": "Duplicate"},
+		},
+		"variations": nil,
+	}
+
+	upgraded, err := upgradeFeatureStateV0(state)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tags := upgraded["tags"]; !reflect.DeepEqual(tags, []interface{}{"a", "b"}) {
+		t.Errorf("expected duplicate tags to be dropped, got %v", tags)
+	}
+	variables := upgraded["variables"].([]interface{})
+	if len(variables) != 2 || variables[0].(map[string]interface{})["name"] != "One" {
+		t.Errorf("expected the first variable of each key to be kept, got %v", variables)
+	}
+	if upgraded["variations"] != nil {
+		t.Errorf("expected null variations to be kept, got %v", upgraded["variations"])
+	}
+}
+
+func TestUpgradeState(t *testing.T) {
+	upgraders := []stateUpgrader{
+		withDefaults(map[string]interface{}{"a": "first"}),
+		func(state map[string]interface{}) (map[string]interface{}, error) {
+			state["b"] = state["a"]
+			return state, nil
+		},
+	}
+
+	upgraded, err := upgradeState([]byte(`{"a":null}`), upgraders)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(upgraded) != `{"a":"first","b":"first"}` {
+		t.Errorf("expected upgraders to run in order, got %s", upgraded)
+	}
+}
+
+// TestProtocol6ServerUpgradeResourceState upgrades state of every resource as
+// written by the first release of each schema version, and checks that the
+// result matches the current schema.
+func TestProtocol6ServerUpgradeResourceState(t *testing.T) {
+	tests := []struct {
+		typeName string
+		version  int64
+		state    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			typeName: "devcycle_project",
+			state: map[string]interface{}{
+				"id":           "id",
+				"key":          "project",
+				"name":         "Project",
+				"description":  "description",
+				"organization": "org",
+			},
+			expected: map[string]interface{}{"key": "project", "etag": nil},
+		},
+		{
+			typeName: "devcycle_environment",
+			state: map[string]interface{}{
+				"id":          "id",
+				"project_id":  "project",
+				"key":         "development",
+				"name":        "Development",
+				"description": "description",
+				"color":       "#000000",
+				"type":        "development",
+				"settings":    map[string]interface{}{"app_icon_uri": ""},
+				"sdk_keys":    []interface{}{"key"},
+			},
+			expected: map[string]interface{}{"key": "development", "etag": nil},
+		},
+		{
+			typeName: "devcycle_feature",
+			state: map[string]interface{}{
+				"id":          "id",
+				"project_id":  "project",
+				"key":         "feature",
+				"name":        "Feature",
+				"description": "description",
+				"type":        "release",
+				"source":      "api",
+				"tags":        []interface{}{"a", "a"},
+				"variables":   nil,
+				"variations":  nil,
+			},
+			expected: map[string]interface{}{"tags": []tftypes.Value{tftypes.NewValue(tftypes.String, "a")}, "status": featureStatusActive},
+		},
+		{
+			typeName: "devcycle_variable",
+			state: map[string]interface{}{
+				"id":          "id",
+				"project_id":  "project",
+				"feature_id":  "feature",
+				"key":         "variable",
+				"name":        "Variable",
+				"description": "description",
+				"type":        "Boolean",
+			},
+			expected: map[string]interface{}{"key": "variable", "status": variableStatusActive},
+		},
+	}
+
+	server := providerserver.NewProtocol6(New("testing")())()
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.typeName, func(t *testing.T) {
+			raw, err := json.Marshal(test.state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := server.UpgradeResourceState(context.Background(), &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: test.typeName,
+				Version:  test.version,
+				RawState: &tfprotov6.RawState{JSON: raw},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, diag := range resp.Diagnostics {
+				t.Errorf("unexpected diagnostic: %s: %s", diag.Summary, diag.Detail)
+			}
+
+			upgraded, err := resp.UpgradedState.Unmarshal(schemas.ResourceSchemas[test.typeName].ValueType())
+			if err != nil {
+				t.Fatalf("upgraded state doesn't match the schema: %s", err)
+			}
+			var attributes map[string]tftypes.Value
+			if err := upgraded.As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+			for name, value := range test.expected {
+				if expected := tftypes.NewValue(attributes[name].Type(), value); !attributes[name].Equal(expected) {
+					t.Errorf("expected %s to be %v, got %v", name, expected, attributes[name])
+				}
+			}
+		})
+	}
+}
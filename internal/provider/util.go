@@ -1,12 +1,21 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"math/rand"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	datasourceschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func randSeq(n int) string {
@@ -18,46 +27,53 @@ func randSeq(n int) string {
 	return string(b)
 }
 
-func userDataSchema() tfsdk.Attribute {
-	return tfsdk.Attribute{
+func userDataSchema() datasourceschema.Attribute {
+	return datasourceschema.SingleNestedAttribute{
 		MarkdownDescription: "User data to drive bucketing into variations for feature flag evaluations.",
 		Required:            true,
-		Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
-			"id": {
+		Attributes: map[string]datasourceschema.Attribute{
+			"id": datasourceschema.StringAttribute{
 				MarkdownDescription: "User ID",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"name": {
+			"name": datasourceschema.StringAttribute{
 				MarkdownDescription: "User name",
 				Optional:            true,
-				Type:                types.StringType,
 			},
-			"app_version": {
+			"app_version": datasourceschema.StringAttribute{
 				MarkdownDescription: "User app version",
 				Optional:            true,
-				Type:                types.StringType,
 			},
-			"email": {
+			"email": datasourceschema.StringAttribute{
 				MarkdownDescription: "User email",
 				Optional:            true,
-				Type:                types.StringType,
 			},
-			"app_build": {
+			"app_build": datasourceschema.StringAttribute{
 				MarkdownDescription: "User app build",
 				Optional:            true,
-				Type:                types.StringType,
 			},
-		}),
-		PlanModifiers: tfsdk.AttributePlanModifiers{
-			tfsdk.RequiresReplace(),
 		},
 	}
 }
 
 func handleDevCycleHTTP(err error, httpResponse *http.Response, resp *diag.Diagnostics) bool {
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusPreconditionFailed {
+		addResourceChangedError(resp)
+		return true
+	}
+	if httpResponse != nil && (httpResponse.StatusCode == http.StatusUnauthorized || httpResponse.StatusCode == http.StatusForbidden) {
+		resp.AddError(
+			"Unable to Authenticate",
+			fmt.Sprintf("The DevCycle API rejected the access token with HTTP %d. Check that the client credentials are valid and have access to the requested project.", httpResponse.StatusCode),
+		)
+		return true
+	}
 	if err != nil || (httpResponse.StatusCode > 299 || httpResponse.StatusCode < 200) {
-		resp.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: %s.\nHTTP Response: %v", err, httpResponse.Request))
+		var request *http.Request
+		if httpResponse != nil {
+			request = httpResponse.Request
+		}
+		resp.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: %s.\nHTTP Response: %v", err, request))
 		return true
 	}
 	return false
@@ -70,3 +86,176 @@ type evaluatedVariableDataSourceDataUser struct {
 	Email      types.String `tfsdk:"email"`
 	AppBuild   types.String `tfsdk:"app_build"`
 }
+
+// typedValueTypes are the DevCycle types of variable values.
+var typedValueTypes = []string{"String", "Number", "Boolean", "JSON"}
+
+// parseTypedValue converts the string representation of a value used in
+// Terraform configuration into the JSON value the management API expects for
+// the given DevCycle type (String, Number, Boolean or JSON).
+func parseTypedValue(valueType, value string) (interface{}, error) {
+	switch valueType {
+	case "Number":
+		return strconv.ParseFloat(value, 64)
+	case "Boolean":
+		return strconv.ParseBool(value)
+	case "JSON":
+		var ret interface{}
+		if err := json.Unmarshal([]byte(value), &ret); err != nil {
+			return nil, err
+		}
+		return ret, nil
+	default:
+		return value, nil
+	}
+}
+
+// formatTypedValue is the inverse of parseTypedValue, converting a value
+// returned by the management API into its Terraform string representation.
+func formatTypedValue(valueType string, value interface{}) (string, error) {
+	if valueType != "JSON" {
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	}
+	marshalled, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(marshalled), nil
+}
+
+// encodeTypedValue converts a value of any Terraform type into the string
+// representation of a value of the given DevCycle type. Strings are treated as
+// already encoded and are validated and normalized, so that e.g. "1.50" and 1.5
+// both encode to "1.5" for a Number.
+func encodeTypedValue(valueType string, value interface{}) (string, error) {
+	if s, ok := value.(string); ok {
+		parsed, err := parseTypedValue(valueType, s)
+		if err != nil {
+			return "", err
+		}
+		value = parsed
+	}
+
+	switch valueType {
+	case "String":
+		if _, ok := value.(string); !ok {
+			return "", fmt.Errorf("expected a string, got %T", value)
+		}
+	case "Number":
+		if _, ok := value.(float64); !ok {
+			return "", fmt.Errorf("expected a number, got %T", value)
+		}
+	case "Boolean":
+		if _, ok := value.(bool); !ok {
+			return "", fmt.Errorf("expected a bool, got %T", value)
+		}
+	case "JSON":
+		if value == nil {
+			return "", fmt.Errorf("expected a JSON value, got null")
+		}
+	default:
+		return "", fmt.Errorf("unknown type %q, expected one of: %s", valueType, strings.Join(typedValueTypes, ", "))
+	}
+	return formatTypedValue(valueType, value)
+}
+
+// typedValueToTF converts a typed value returned by the management API into its
+// Terraform string representation. The prior value is kept when it is
+// equivalent, e.g. "1.0" for the Number 1, to avoid spurious differences.
+func typedValueToTF(valueType string, value interface{}, prior types.String) types.String {
+	if value == nil {
+		return types.StringNull()
+	}
+	formatted, err := formatTypedValue(valueType, value)
+	if err != nil {
+		formatted = fmt.Sprintf("%v", value)
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if parsed, err := parseTypedValue(valueType, prior.ValueString()); err == nil {
+			if priorFormatted, err := formatTypedValue(valueType, parsed); err == nil && priorFormatted == formatted {
+				return prior
+			}
+		}
+	}
+	return types.StringValue(formatted)
+}
+
+var (
+	keyWordBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+	keyInvalidChars = regexp.MustCompile(`[^a-z0-9_.]+`)
+)
+
+// maxKeyLength is the longest key accepted by the management API.
+const maxKeyLength = 100
+
+// normalizeKey converts a display name such as "New Checkout Flow" or
+// "newCheckoutFlow" into the kebab case key DevCycle expects, i.e.
+// "new-checkout-flow".
+func normalizeKey(name string) (string, error) {
+	key := keyWordBoundary.ReplaceAllString(name, "$1-$2")
+	key = keyInvalidChars.ReplaceAllString(strings.ToLower(key), "-")
+	key = strings.Trim(key, "-")
+	if key == "" {
+		return "", fmt.Errorf("name %q doesn't contain any character allowed in a key", name)
+	}
+	if len(key) > maxKeyLength {
+		return "", fmt.Errorf("key %q is longer than %d characters", key, maxKeyLength)
+	}
+	return key, nil
+}
+
+// importProjectScopedKey handles import IDs of the form <project>/<key> for
+// resources that are addressed by key within a project.
+func importProjectScopedKey(ctx context.Context, projectAttribute string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := splitImportID(req.ID, 2)
+	if parts == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <project>/<key>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(projectAttribute), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), parts[1])...)
+}
+
+// splitImportID splits a composite import ID into exactly n non-empty parts
+// separated by "/", returning nil if the ID doesn't match.
+func splitImportID(id string, n int) []string {
+	parts := strings.SplitN(id, "/", n)
+	if len(parts) != n {
+		return nil
+	}
+	for _, part := range parts {
+		if part == "" {
+			return nil
+		}
+	}
+	return parts
+}
+
+// addResourceChangedError reports a write rejected because the etag sent as
+// If-Match no longer matches the resource.
+func addResourceChangedError(diags *diag.Diagnostics) {
+	diags.AddError(
+		"Resource Changed Outside Terraform",
+		"The resource was changed outside of Terraform since it was last read, so the change was not applied. Refresh the state and re-plan before applying again.",
+	)
+}
+
+// etagAttribute is the schema of the computed etag attribute of resources
+// supporting optimistic concurrency.
+func etagAttribute() schema.Attribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Version of the resource as last read from DevCycle. It is sent with updates and deletes so that changes made outside of Terraform in the meantime are detected instead of overwritten.",
+		Computed:            true,
+	}
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// stringOneOfValidator validates that a string attribute is one of a fixed set
+// of values, e.g. the types accepted by the management API.
+type stringOneOfValidator struct {
+	values []string
+}
+
+func stringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{values: values}
+}
+
+func (v stringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	quoted := make([]string, len(v.values))
+	for i, value := range v.values {
+		quoted[i] = "`" + value + "`"
+	}
+	return fmt.Sprintf("value must be one of: %s", strings.Join(quoted, ", "))
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	value := req.ConfigValue
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	for _, allowed := range v.values {
+		if value.ValueString() == allowed {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute value %q is invalid, %s.", value.ValueString(), v.Description(ctx)),
+	)
+}
@@ -2,75 +2,62 @@ package provider
 
 import (
 	"context"
-	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type variableDataSourceType struct{}
+var _ datasource.DataSourceWithConfigure = &variableDataSource{}
+
+func newVariableDataSource() datasource.DataSource {
+	return &variableDataSource{}
+}
+
+func (d *variableDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variable"
+}
 
-func (t variableDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
+func (d *variableDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "DevCycle Variable data source",
 
-		Attributes: map[string]tfsdk.Attribute{
-			"key": {
+		Attributes: map[string]schema.Attribute{
+			"key": schema.StringAttribute{
 				MarkdownDescription: "Variable key",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"feature_id": {
+			"feature_id": schema.StringAttribute{
 				MarkdownDescription: "Feature ID",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"project_id": {
+			"project_id": schema.StringAttribute{
 				MarkdownDescription: "Project ID",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"project_key": {
+			"project_key": schema.StringAttribute{
 				MarkdownDescription: "Project key",
 				Required:            true,
-				Type:                types.StringType,
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
-				},
 			},
-			"id": {
+			"id": schema.StringAttribute{
 				MarkdownDescription: "Variable Key",
 				Computed:            true,
-				Type:                types.StringType,
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
-				},
 			},
-			"name": {
+			"name": schema.StringAttribute{
 				MarkdownDescription: "Variable name",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"description": {
+			"description": schema.StringAttribute{
 				MarkdownDescription: "Variable description",
 				Computed:            true,
-				Type:                types.StringType,
 			},
-			"type": {
+			"type": schema.StringAttribute{
 				MarkdownDescription: "Variable type",
 				Computed:            true,
-				Type:                types.StringType,
 			},
 		},
-	}, nil
-}
-
-func (t variableDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return variableDataSource{
-		provider: provider,
-	}, diags
+	}
 }
 
 type variableDataSourceData struct {
@@ -85,16 +72,12 @@ type variableDataSourceData struct {
 }
 
 type variableDataSource struct {
-	provider provider
+	dataSourceBase
 }
 
-func (d variableDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+func (d *variableDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data variableDataSourceData
-	if !d.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !d.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.Config.Get(ctx, &data)
@@ -103,17 +86,17 @@ func (d variableDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	variable, httpResponse, err := d.provider.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, data.Key.Value, data.ProjectKey.Value)
+	variable, httpResponse, err := d.provider.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, data.Key.ValueString(), data.ProjectKey.ValueString())
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
-	data.Id = types.String{Value: variable.Id}
-	data.Key = types.String{Value: variable.Key}
-	data.Name = types.String{Value: variable.Name}
-	data.Description = types.String{Value: variable.Description}
-	data.Type = types.String{Value: variable.Type_}
-	data.FeatureId = types.String{Value: variable.Feature}
-	data.ProjectId = types.String{Value: variable.Project}
+	data.Id = types.StringValue(variable.Id)
+	data.Key = types.StringValue(variable.Key)
+	data.Name = types.StringValue(variable.Name)
+	data.Description = types.StringValue(variable.Description)
+	data.Type = types.StringValue(variable.Type_)
+	data.FeatureId = types.StringValue(variable.Feature)
+	data.ProjectId = types.StringValue(variable.Project)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -2,102 +2,415 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
 	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-type variableResourceType struct{}
+var _ resource.ResourceWithConfigure = &variableResource{}
+var _ resource.ResourceWithImportState = &variableResource{}
+var _ resource.ResourceWithUpgradeState = &variableResource{}
+var _ resource.ResourceWithValidateConfig = &variableResource{}
+var _ resource.ResourceWithModifyPlan = &variableResource{}
+
+func newVariableResource() resource.Resource {
+	return &variableResource{}
+}
+
+func (r *variableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variable"
+}
+
+func (r *variableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// Version 1 added default_value, validation_schema, lifecycle_mode, status,
+		// archived_at and etag.
+		Version: 1,
 
-func (t variableResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
-	return tfsdk.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "DevCycle Variable resource",
 
-		Attributes: map[string]tfsdk.Attribute{
-			"name": {
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
 				MarkdownDescription: "Variable name",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"description": {
+			"description": schema.StringAttribute{
 				MarkdownDescription: "Variable description",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"key": {
+			"key": schema.StringAttribute{
 				MarkdownDescription: "Variable key",
 				Required:            true,
-				Type:                types.StringType,
 			},
-			"feature_id": {
+			"feature_id": schema.StringAttribute{
 				MarkdownDescription: "Feature that this variable is attached to",
 				Required:            true,
-				Type:                types.StringType,
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"project_id": {
+			"project_id": schema.StringAttribute{
 				MarkdownDescription: "Project id that this feature and variable is attached to",
 				Required:            true,
-				Type:                types.StringType,
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"type": {
+			"type": schema.StringAttribute{
 				MarkdownDescription: "Variable datatype",
 				Required:            true,
-				Type:                types.StringType,
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"default_value": schema.StringAttribute{
+				MarkdownDescription: "Default value of the variable, encoded as a string. The value is converted to the variable `type`; JSON values must be encoded with `jsonencode`.",
+				Optional:            true,
+			},
+			"validation_schema": validationSchemaAttribute(),
+			"lifecycle_mode": schema.StringAttribute{
+				MarkdownDescription: "What happens to the variable when it is destroyed. `delete` (the default) permanently deletes the variable, `archive` archives it so that its history is kept for SDK code still referencing the key. Applying the configuration again unarchives an archived variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(variableLifecycleDelete, variableLifecycleArchive),
 				},
 			},
-			"id": {
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the variable, either `active` or `archived`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"archived_at": schema.StringAttribute{
+				MarkdownDescription: "Time the variable was archived, if it is archived",
+				Computed:            true,
+			},
+			"etag": etagAttribute(),
+			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Variable ID",
-				PlanModifiers: tfsdk.AttributePlanModifiers{
-					tfsdk.RequiresReplace(),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
 				},
-				Type: types.StringType,
 			},
 		},
-	}, nil
+	}
 }
 
-func (t variableResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
-	provider, diags := convertProviderType(in)
-
-	return variableResource{
-		provider: provider,
-	}, diags
+func (r *variableResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(
+		withDefaults(map[string]interface{}{
+			"default_value":     nil,
+			"validation_schema": nil,
+			"lifecycle_mode":    nil,
+			"status":            variableStatusActive,
+			"archived_at":       nil,
+			"etag":              nil,
+		}),
+	)
 }
 
 type variableResourceData struct {
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Key         types.String `tfsdk:"key"`
-	FeatureId   types.String `tfsdk:"feature_id"`
-	ProjectId   types.String `tfsdk:"project_id"`
-	Type        types.String `tfsdk:"type"`
-	Id          types.String `tfsdk:"id"`
+	Name             types.String                          `tfsdk:"name"`
+	Description      types.String                          `tfsdk:"description"`
+	Key              types.String                          `tfsdk:"key"`
+	FeatureId        types.String                          `tfsdk:"feature_id"`
+	ProjectId        types.String                          `tfsdk:"project_id"`
+	Type             types.String                          `tfsdk:"type"`
+	DefaultValue     types.String                          `tfsdk:"default_value"`
+	ValidationSchema *variableResourceDataValidationSchema `tfsdk:"validation_schema"`
+	LifecycleMode    types.String                          `tfsdk:"lifecycle_mode"`
+	Status           types.String                          `tfsdk:"status"`
+	ArchivedAt       types.String                          `tfsdk:"archived_at"`
+	Id               types.String                          `tfsdk:"id"`
+	Etag             types.String                          `tfsdk:"etag"`
+}
+
+const (
+	variableLifecycleDelete  = "delete"
+	variableLifecycleArchive = "archive"
+
+	variableStatusActive   = "active"
+	variableStatusArchived = "archived"
+)
+
+// variableWithValidation extends the go-mgmt-sdk variable model with the
+// validation schema, which the generated client doesn't know about. Variables
+// are written and read with doMgmtJSONRequest so that it round-trips.
+type variableWithValidation struct {
+	devcyclem.Variable
+	ValidationSchema *variableValidationSchema `json:"validationSchema,omitempty"`
+	Status           string                    `json:"status,omitempty"`
+	ArchivedAt       *time.Time                `json:"archivedAt,omitempty"`
+}
+
+type variableWriteDto struct {
+	Name             string                    `json:"name,omitempty"`
+	Description      string                    `json:"description,omitempty"`
+	Key              string                    `json:"key,omitempty"`
+	Feature          string                    `json:"_feature,omitempty"`
+	Type_            string                    `json:"type,omitempty"`
+	DefaultValue     interface{}               `json:"defaultValue,omitempty"`
+	ValidationSchema *variableValidationSchema `json:"validationSchema,omitempty"`
+}
+
+// variableUpdateDto is the PATCH body of a variable. Unlike variableWriteDto it
+// always sends the description, default value and validation schema, so that
+// removing them from the configuration clears them. The type and feature of a
+// variable can't be changed.
+type variableUpdateDto struct {
+	Name             string                    `json:"name,omitempty"`
+	Description      string                    `json:"description"`
+	Key              string                    `json:"key,omitempty"`
+	DefaultValue     interface{}               `json:"defaultValue"`
+	ValidationSchema *variableValidationSchema `json:"validationSchema"`
+}
+
+// variableRestoreDto is the PATCH body of a restored archived variable. The
+// variable may have been archived while attached to another feature, so it
+// is also moved to the configured one.
+type variableRestoreDto struct {
+	variableUpdateDto
+	Feature string `json:"_feature,omitempty"`
+}
+
+func (d variableWriteDto) update() variableUpdateDto {
+	return variableUpdateDto{
+		Name:             d.Name,
+		Description:      d.Description,
+		Key:              d.Key,
+		DefaultValue:     d.DefaultValue,
+		ValidationSchema: d.ValidationSchema,
+	}
+}
+
+func (d variableResourceData) toSDK(ctx context.Context, diags *diag.Diagnostics) variableWriteDto {
+	ret := variableWriteDto{
+		Name:        d.Name.ValueString(),
+		Description: d.Description.ValueString(),
+		Key:         d.Key.ValueString(),
+		Feature:     d.FeatureId.ValueString(),
+		Type_:       d.Type.ValueString(),
+	}
+	if isSetString(d.DefaultValue) {
+		defaultValue, err := parseTypedValue(d.Type.ValueString(), d.DefaultValue.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("default_value"), "Invalid Default Value", fmt.Sprintf("Unable to convert default value to %s: %s", d.Type.ValueString(), err))
+		}
+		ret.DefaultValue = defaultValue
+	}
+	if d.ValidationSchema != nil {
+		ret.ValidationSchema = d.ValidationSchema.toSDK(ctx, d.Type.ValueString(), diags)
+	}
+	return ret
+}
+
+func (d *variableResourceData) fromSDK(variable variableWithValidation) {
+	d.Id = types.StringValue(variable.Id)
+	d.Key = types.StringValue(variable.Key)
+	d.Name = types.StringValue(variable.Name)
+	d.Description = types.StringValue(variable.Description)
+	d.Type = types.StringValue(variable.Type_)
+	d.FeatureId = types.StringValue(variable.Feature)
+	d.ProjectId = types.StringValue(variable.Project)
+	d.DefaultValue = typedValueToTF(variable.Type_, variable.DefaultValue, d.DefaultValue)
+	d.ValidationSchema = validationSchemaToTF(variable.ValidationSchema, variable.Type_, d.ValidationSchema)
+
+	d.Status = types.StringValue(variableStatusActive)
+	d.ArchivedAt = types.StringNull()
+	if variable.Status == variableStatusArchived {
+		d.Status = types.StringValue(variableStatusArchived)
+		archivedAt := variable.UpdatedAt
+		if variable.ArchivedAt != nil {
+			archivedAt = *variable.ArchivedAt
+		}
+		d.ArchivedAt = types.StringValue(archivedAt.Format(time.RFC3339))
+	}
+}
+
+// remoteEqual reports whether d and o hold the same values read from
+// DevCycle, regardless of their etag.
+func (d variableResourceData) remoteEqual(o variableResourceData) bool {
+	values := [][2]attr.Value{
+		{d.Id, o.Id},
+		{d.Key, o.Key},
+		{d.Name, o.Name},
+		{d.Description, o.Description},
+		{d.Type, o.Type},
+		{d.FeatureId, o.FeatureId},
+		{d.ProjectId, o.ProjectId},
+		{d.DefaultValue, o.DefaultValue},
+		{d.Status, o.Status},
+		{d.ArchivedAt, o.ArchivedAt},
+	}
+	if (d.ValidationSchema == nil) != (o.ValidationSchema == nil) {
+		return false
+	}
+	if s, t := d.ValidationSchema, o.ValidationSchema; s != nil {
+		values = append(values, [][2]attr.Value{
+			{s.EnumValues, t.EnumValues},
+			{s.RegexPattern, t.RegexPattern},
+			{s.MinValue, t.MinValue},
+			{s.MaxValue, t.MaxValue},
+			{s.JSONSchema, t.JSONSchema},
+			{s.Description, t.Description},
+		}...)
+	}
+	for _, pair := range values {
+		if !pair[0].Equal(pair[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+func variablePath(project, key string) string {
+	path := fmt.Sprintf("/v1/projects/%s/variables", url.PathEscape(project))
+	if key != "" {
+		path += "/" + url.PathEscape(key)
+	}
+	return path
 }
 
 type variableResource struct {
-	provider provider
+	resourceBase
 }
 
-func (r variableResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+// writeEtag returns the etag to send with a write of the variable in state.
+// Variables refreshed from a prefetched list have no etag, see
+// prefetchMgmtList: the variable is then read again with withMgmtETag, and its etag
+// is only used when it still matches the state, so that changes made since
+// the refresh are detected as if the etag had been stored.
+func (r *variableResource) writeEtag(ctx context.Context, state variableResourceData, diags *diag.Diagnostics) (types.String, bool) {
+	if isSetString(state.Etag) {
+		return state.Etag, false
+	}
+
+	var variable variableWithValidation
+	httpResponse, err := r.provider.doMgmtJSONRequest(withMgmtETag(ctx), http.MethodGet, variablePath(state.ProjectId.ValueString(), state.Key.ValueString()), nil, nil, &variable)
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+		// Left to the write to report.
+		return state.Etag, false
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, diags); ret {
+		return state.Etag, true
+	}
+	current := state
+	current.fromSDK(variable)
+	if !current.remoteEqual(state) {
+		addResourceChangedError(diags)
+		return state.Etag, true
+	}
+	return etagToTF(httpResponse), false
+}
+
+// unarchive restores an archived variable with the given key and applies the
+// configured values, including its feature, to it. Any other conflict is reported as an error.
+func (r *variableResource) unarchive(ctx context.Context, key, projectID string, body variableWriteDto, diags *diag.Diagnostics) (variableWithValidation, *http.Response, bool) {
+	var existing variableWithValidation
+	httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodGet, variablePath(projectID, key), nil, nil, &existing)
+	if ret := handleDevCycleHTTP(err, httpResponse, diags); ret {
+		return existing, httpResponse, true
+	}
+	if existing.Status != variableStatusArchived {
+		diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: a variable with key %q already exists in project %q", key, projectID))
+		return existing, httpResponse, true
+	}
+	if existing.Type_ != body.Type_ {
+		diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: archived variable %q has type %s and can't be restored as %s", key, existing.Type_, body.Type_))
+		return existing, httpResponse, true
+	}
+
+	_, httpResponse, ret := r.provider.setVariableStatus(withIfMatch(ctx, etagToTF(httpResponse)), key, projectID, variableStatusActive, diags)
+	if ret {
+		return existing, httpResponse, true
+	}
+
+	var variable variableWithValidation
+	httpResponse, err = r.provider.doMgmtJSONRequest(withIfMatch(ctx, etagToTF(httpResponse)), http.MethodPatch, variablePath(projectID, key), nil, variableRestoreDto{
+		variableUpdateDto: body.update(),
+		Feature:           body.Feature,
+	}, &variable)
+	if ret := handleDevCycleHTTP(err, httpResponse, diags); ret {
+		return variable, httpResponse, true
+	}
+	return variable, httpResponse, false
+}
+
+func (r *variableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.warnRemoteKeyCollision(ctx, req, resp, variableKeyCheck)
+
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	// A variable archived outside of Terraform, or by a previous destroy, is
+	// unarchived on the next apply.
+	var status types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("status"), &status)...)
+	if resp.Diagnostics.HasError() || status.ValueString() != variableStatusArchived {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), types.StringValue(variableStatusActive))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("archived_at"), types.StringUnknown())...)
+}
+
+func (r *variableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var variableType, defaultValue types.String
+	var validationSchema types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &variableType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("default_value"), &defaultValue)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("validation_schema"), &validationSchema)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var validation *variableResourceDataValidationSchema
+	if !validationSchema.IsNull() && !validationSchema.IsUnknown() {
+		validation = &variableResourceDataValidationSchema{}
+		resp.Diagnostics.Append(validationSchema.As(ctx, validation, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		validation.validate(ctx, variableType, path.Root("validation_schema"), &resp.Diagnostics)
+	}
+
+	if !isSetString(variableType) || !isSetString(defaultValue) {
+		return
+	}
+	defaultValuePath := path.Root("default_value")
+	parsed, err := parseTypedValue(variableType.ValueString(), defaultValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(defaultValuePath, "Invalid Default Value", fmt.Sprintf("Unable to convert default value to %s: %s", variableType.ValueString(), err))
+		return
+	}
+	if validation == nil || !validation.known() || resp.Diagnostics.HasError() {
+		return
+	}
+	if err := validation.toSDK(ctx, variableType.ValueString(), &resp.Diagnostics).validateValue(variableType.ValueString(), parsed); err != nil {
+		resp.Diagnostics.AddAttributeError(defaultValuePath, "Invalid Default Value", fmt.Sprintf("The default value doesn't satisfy the validation schema: %s", err))
+	}
+}
+
+func (r *variableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data variableResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.Config.Get(ctx, &data)
@@ -107,23 +420,26 @@ func (r variableResource) Create(ctx context.Context, req tfsdk.CreateResourceRe
 		return
 	}
 
-	variable, httpResponse, err := r.provider.MgmtClient.VariablesApi.VariablesControllerCreate(ctx, devcyclem.CreateVariableDto{
-		Name:        data.Name.Value,
-		Description: data.Description.Value,
-		Key:         data.Key.Value,
-		Feature:     data.FeatureId.Value,
-		Type_:       data.Type.Value,
-	}, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	body := data.toSDK(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var variable variableWithValidation
+	httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodPost, variablePath(data.ProjectId.ValueString(), ""), nil, body, &variable)
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusConflict {
+		// The key may belong to a variable archived by a previous destroy,
+		// in which case it is unarchived and updated instead.
+		var ret bool
+		variable, httpResponse, ret = r.unarchive(ctx, data.Key.ValueString(), data.ProjectId.ValueString(), body, &resp.Diagnostics)
+		if ret {
+			return
+		}
+	} else if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
-	data.Id = types.String{Value: variable.Id}
-	data.Key = types.String{Value: variable.Key}
-	data.Name = types.String{Value: variable.Name}
-	data.Description = types.String{Value: variable.Description}
-	data.Type = types.String{Value: variable.Type_}
-	data.FeatureId = types.String{Value: variable.Feature}
-	data.ProjectId = types.String{Value: variable.Project}
+	data.fromSDK(variable)
+	data.Etag = etagToTF(httpResponse)
 
 	// write logs using the tflog package
 	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
@@ -134,13 +450,9 @@ func (r variableResource) Create(ctx context.Context, req tfsdk.CreateResourceRe
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r variableResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+func (r *variableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data variableResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.State.Get(ctx, &data)
@@ -150,29 +462,22 @@ func (r variableResource) Read(ctx context.Context, req tfsdk.ReadResourceReques
 		return
 	}
 
-	variable, httpResponse, err := r.provider.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, data.Key.Value, data.ProjectId.Value)
+	r.provider.prefetchMgmtList(ctx, variablePath(data.ProjectId.ValueString(), ""))
+	var variable variableWithValidation
+	httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodGet, variablePath(data.ProjectId.ValueString(), data.Key.ValueString()), nil, nil, &variable)
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
-	data.Id = types.String{Value: variable.Id}
-	data.Key = types.String{Value: variable.Key}
-	data.Name = types.String{Value: variable.Name}
-	data.Description = types.String{Value: variable.Description}
-	data.Type = types.String{Value: variable.Type_}
-	data.FeatureId = types.String{Value: variable.Feature}
-	data.ProjectId = types.String{Value: variable.Project}
+	data.fromSDK(variable)
+	data.Etag = etagToTF(httpResponse)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r variableResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+func (r *variableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data variableResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.Plan.Get(ctx, &data)
@@ -181,34 +486,43 @@ func (r variableResource) Update(ctx context.Context, req tfsdk.UpdateResourceRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	variable, httpResponse, err := r.provider.MgmtClient.VariablesApi.VariablesControllerUpdate(ctx, devcyclem.UpdateVariableDto{
-		Name:        data.Name.Value,
-		Description: data.Description.Value,
-		Key:         data.Key.Value,
-		Feature:     data.FeatureId.Value,
-	}, data.Id.Value, data.ProjectId.Value)
+	body := data.toSDK(ctx, &resp.Diagnostics).update()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state variableResourceData
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	etag, ret := r.writeEtag(ctx, state, &resp.Diagnostics)
+	if ret {
+		return
+	}
+	if state.Status.ValueString() == variableStatusArchived {
+		_, httpResponse, ret := r.provider.setVariableStatus(withIfMatch(ctx, etag), data.Id.ValueString(), data.ProjectId.ValueString(), variableStatusActive, &resp.Diagnostics)
+		if ret {
+			return
+		}
+		etag = etagToTF(httpResponse)
+	}
+
+	var variable variableWithValidation
+	httpResponse, err := r.provider.doMgmtJSONRequest(withIfMatch(ctx, etag), http.MethodPatch, variablePath(data.ProjectId.ValueString(), data.Id.ValueString()), nil, body, &variable)
 	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
 		return
 	}
-	data.Id = types.String{Value: variable.Id}
-	data.Key = types.String{Value: variable.Key}
-	data.Name = types.String{Value: variable.Name}
-	data.Description = types.String{Value: variable.Description}
-	data.Type = types.String{Value: variable.Type_}
-	data.FeatureId = types.String{Value: variable.Feature}
-	data.ProjectId = types.String{Value: variable.Project}
+	data.fromSDK(variable)
+	data.Etag = etagToTF(httpResponse)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
-func (r variableResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+func (r *variableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data variableResourceData
-	if !r.provider.configured {
-		resp.Diagnostics.AddError(
-			"Provider not configured",
-			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
-		)
+	if !r.requireMgmtClient(&resp.Diagnostics) {
 		return
 	}
 	diags := req.State.Get(ctx, &data)
@@ -218,13 +532,21 @@ func (r variableResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRe
 		return
 	}
 
-	if ret := r.provider.variablesControllerDelete(ctx, data.Key.Value, data.ProjectId.Value, &resp.Diagnostics); ret {
+	etag, ret := r.writeEtag(ctx, data, &resp.Diagnostics)
+	if ret {
+		return
+	}
+	if data.LifecycleMode.ValueString() == variableLifecycleArchive {
+		if ret := r.provider.variablesControllerArchive(ctx, data.Key.ValueString(), data.ProjectId.ValueString(), etag, &resp.Diagnostics); ret {
+			return
+		}
+	} else if ret := r.provider.variablesControllerDelete(ctx, data.Key.ValueString(), data.ProjectId.ValueString(), etag, &resp.Diagnostics); ret {
 		return
 	}
 
 	resp.State.RemoveResource(ctx)
 }
 
-func (r variableResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+func (r *variableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importProjectScopedKey(ctx, "project_id", req, resp)
 }
@@ -1,9 +1,18 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	dvc_mgmt "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccVariableResource(t *testing.T) {
@@ -17,6 +26,26 @@ func TestAccVariableResource(t *testing.T) {
 				Config: testAccVariableResourceConfig(),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("devcycle_variable.test", "key", testAccVariableResourceKey()),
+					resource.TestCheckResourceAttr("devcycle_variable.test", "default_value", "false"),
+				),
+			},
+			{
+				Config: testAccVariableResourceConfigValidation(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_variable.validated", "default_value", "blue"),
+					resource.TestCheckResourceAttr("devcycle_variable.validated", "validation_schema.enum_values.#", "2"),
+				),
+			},
+			{
+				Config:      testAccVariableResourceConfigInvalidDefault(),
+				ExpectError: regexp.MustCompile("Invalid Default Value"),
+			},
+			// Removing the default value and the validation schema clears them.
+			{
+				Config: testAccVariableResourceConfigCleared(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("devcycle_variable.validated", "default_value"),
+					resource.TestCheckNoResourceAttr("devcycle_variable.validated", "validation_schema"),
 				),
 			},
 			{
@@ -27,6 +56,35 @@ func TestAccVariableResource(t *testing.T) {
 	})
 }
 
+func TestAccVariableResourceArchive(t *testing.T) {
+	testAccPreCheck(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVariableResourceConfigArchive(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_variable.archived", "lifecycle_mode", "archive"),
+					resource.TestCheckResourceAttr("devcycle_variable.archived", "status", "active"),
+				),
+			},
+			{
+				Config:  testAccVariableResourceConfigArchive(),
+				Destroy: true,
+			},
+			// Applying again restores the archived variable.
+			{
+				Config: testAccVariableResourceConfigArchive(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_variable.archived", "status", "active"),
+					resource.TestCheckNoResourceAttr("devcycle_variable.archived", "archived_at"),
+				),
+			},
+		},
+	})
+}
+
 func testAccVariableResourceKey() string {
 	return "terraform-acceptance-testing" + randString
 }
@@ -40,6 +98,207 @@ resource "devcycle_variable" "test" {
   type = "Boolean"
   feature_id = "622115014b06357d06d1cf3e"
   project_id = "622112634cabe0e9fbaf974d"
+  default_value = "false"
+}
+`
+}
+
+func testAccVariableResourceConfigArchive() string {
+	return `
+resource "devcycle_variable" "archived" {
+  name = "TerraformAccTest` + randString + `archived"
+  key = "` + testAccVariableResourceKey() + `-archived"
+  description = "Terraform acceptance testing"
+  type = "Boolean"
+  feature_id = "622115014b06357d06d1cf3e"
+  project_id = "622112634cabe0e9fbaf974d"
+  lifecycle_mode = "archive"
 }
 `
 }
+
+func testAccVariableResourceConfigValidation() string {
+	return testAccVariableResourceConfig() + `
+resource "devcycle_variable" "validated" {
+  name = "TerraformAccTest` + randString + `2"
+  key = "` + testAccVariableResourceKey() + `2"
+  description = "Terraform acceptance testing"
+  type = "String"
+  feature_id = "622115014b06357d06d1cf3e"
+  project_id = "622112634cabe0e9fbaf974d"
+  default_value = "blue"
+  validation_schema = {
+    enum_values = ["blue", "green"]
+  }
+}
+`
+}
+
+func testAccVariableResourceConfigInvalidDefault() string {
+	return testAccVariableResourceConfig() + `
+resource "devcycle_variable" "validated" {
+  name = "TerraformAccTest` + randString + `2"
+  key = "` + testAccVariableResourceKey() + `2"
+  description = "Terraform acceptance testing"
+  type = "String"
+  feature_id = "622115014b06357d06d1cf3e"
+  project_id = "622112634cabe0e9fbaf974d"
+  default_value = "red"
+  validation_schema = {
+    enum_values = ["blue", "green"]
+  }
+}
+`
+}
+
+func testAccVariableResourceConfigCleared() string {
+	return testAccVariableResourceConfig() + `
+resource "devcycle_variable" "validated" {
+  name = "TerraformAccTest` + randString + `2"
+  key = "` + testAccVariableResourceKey() + `2"
+  description = "Terraform acceptance testing"
+  type = "String"
+  feature_id = "622115014b06357d06d1cf3e"
+  project_id = "622112634cabe0e9fbaf974d"
+}
+`
+}
+
+func TestVariableUpdateDto(t *testing.T) {
+	body, err := json.Marshal(variableWriteDto{Name: "Variable", Key: "variable", Type_: "String", Feature: "feature"}.update())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"name":"Variable","description":"","key":"variable","defaultValue":null,"validationSchema":null}`; string(body) != expected {
+		t.Errorf("expected the cleared fields to be sent, got %s", body)
+	}
+}
+
+func TestVariablesControllerDeleteIfMatch(t *testing.T) {
+	attached := true
+	ifMatch := map[string]string{}
+	transport := mgmtTestTransport(func(req *http.Request) (*http.Response, error) {
+		status, etag, body := http.StatusOK, "", ""
+		switch req.Method + " " + req.URL.Path {
+		case "GET /v1/projects/project/variables/variable":
+			etag, body = `"v1"`, `{"_id": "variable-id", "key": "variable", "_feature": "feature-id"}`
+			if !attached {
+				etag, body = `"v2"`, `{"_id": "variable-id", "key": "variable"}`
+			}
+		case "GET /v1/projects/project/features/feature-id":
+			etag, body = `"f1"`, `{"_id": "feature-id", "key": "feature", "variables": [{"_id": "variable-id", "key": "variable"}]}`
+		case "PATCH /v1/projects/project/features/feature":
+			attached = false
+			body = `{"_id": "feature-id", "key": "feature"}`
+		case "DELETE /v1/projects/project/variables/variable":
+			status = http.StatusNoContent
+		default:
+			status, body = http.StatusNotFound, `{"message": "Not Found"}`
+		}
+		ifMatch[req.Method+" "+req.URL.Path] = req.Header.Get("If-Match")
+		header := http.Header{"Content-Type": []string{"application/json"}}
+		if etag != "" {
+			header.Set("ETag", etag)
+		}
+		return &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	httpClient := &http.Client{Transport: retryTransport{base: transport}}
+	config := dvc_mgmt.NewConfiguration()
+	config.HTTPClient = httpClient
+	config.BasePath = "https://api.devcycle.com"
+	p := &devcycleProvider{MgmtHTTPClient: httpClient, MgmtClient: dvc_mgmt.NewAPIClient(config)}
+
+	var diags diag.Diagnostics
+	if ret := p.variablesControllerDelete(context.Background(), "variable", "project", types.StringValue(`"v1"`), &diags); ret {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if got := ifMatch["PATCH /v1/projects/project/features/feature"]; got != `"f1"` {
+		t.Errorf("expected the feature etag on detach, got %q", got)
+	}
+	if got := ifMatch["DELETE /v1/projects/project/variables/variable"]; got != `"v2"` {
+		t.Errorf("expected the detached variable etag on delete, got %q", got)
+	}
+}
+
+func TestVariableResourceWriteEtag(t *testing.T) {
+	body := `{"_id": "variable-id", "_project": "project", "key": "variable", "name": "Variable", "type": "String", "defaultValue": "on"}`
+	var requests []string
+	transport := mgmtTestTransport(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Header:     http.Header{"Content-Type": []string{"application/json"}, "Etag": []string{`"v1"`}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	r := &variableResource{resourceBase{providerBase{provider: &devcycleProvider{MgmtHTTPClient: &http.Client{Transport: transport}}}}}
+
+	// The state of a variable refreshed from a prefetched list.
+	var variable variableWithValidation
+	if err := json.Unmarshal([]byte(body), &variable); err != nil {
+		t.Fatal(err)
+	}
+	state := variableResourceData{LifecycleMode: types.StringValue(variableLifecycleDelete)}
+	state.fromSDK(variable)
+
+	var diags diag.Diagnostics
+	if etag, ret := r.writeEtag(context.Background(), state, &diags); ret || etag.ValueString() != `"v1"` {
+		t.Errorf("expected the etag of the unchanged variable, got %v and %v", etag, diags)
+	}
+
+	requests = nil
+	state.Etag = types.StringValue(`"v0"`)
+	if etag, ret := r.writeEtag(context.Background(), state, &diags); ret || etag.ValueString() != `"v0"` || len(requests) != 0 {
+		t.Errorf("expected the stored etag to be used, got %v and requests %v", etag, requests)
+	}
+
+	state.Etag = types.StringNull()
+	body = `{"_id": "variable-id", "_project": "project", "key": "variable", "name": "Renamed", "type": "String", "defaultValue": "on"}`
+	if _, ret := r.writeEtag(context.Background(), state, &diags); !ret || diags[0].Summary() != "Resource Changed Outside Terraform" {
+		t.Errorf("expected a resource changed error, got %v", diags)
+	}
+}
+
+func TestVariableResourceUnarchiveMovesFeature(t *testing.T) {
+	var restore map[string]interface{}
+	transport := mgmtTestTransport(func(req *http.Request) (*http.Response, error) {
+		body := `{"_id": "variable-id", "key": "variable", "type": "String", "_feature": "old-feature-id", "status": "archived"}`
+		switch req.Method + " " + req.URL.Path {
+		case "PATCH /v1/projects/project/variables/variable/status":
+			body = `{"_id": "variable-id", "key": "variable", "type": "String", "_feature": "old-feature-id", "status": "active"}`
+		case "PATCH /v1/projects/project/variables/variable":
+			if err := json.NewDecoder(req.Body).Decode(&restore); err != nil {
+				t.Fatal(err)
+			}
+			body = `{"_id": "variable-id", "key": "variable", "type": "String", "_feature": "new-feature-id", "status": "active"}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	r := &variableResource{resourceBase{providerBase{provider: &devcycleProvider{MgmtHTTPClient: &http.Client{Transport: transport}}}}}
+
+	var diags diag.Diagnostics
+	variable, _, ret := r.unarchive(context.Background(), "variable", "project", variableWriteDto{Key: "variable", Type_: "String", Feature: "new-feature-id"}, &diags)
+	if ret {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	if restore["_feature"] != "new-feature-id" {
+		t.Errorf("expected the variable to be moved to the configured feature, got %v", restore)
+	}
+	if variable.Feature != "new-feature-id" {
+		t.Errorf("expected the restored variable, got %+v", variable)
+	}
+}
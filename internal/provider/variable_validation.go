@@ -0,0 +1,399 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// variableValidationSchema mirrors the management API variable validation
+// schema. Ranges configured with min_value/max_value are sent as a JSON schema,
+// as the API has no dedicated range schema type.
+type variableValidationSchema struct {
+	SchemaType   string        `json:"schemaType"`
+	EnumValues   []interface{} `json:"enumValues,omitempty"`
+	RegexPattern string        `json:"regexPattern,omitempty"`
+	JSONSchema   string        `json:"jsonSchema,omitempty"`
+	Description  string        `json:"description,omitempty"`
+}
+
+// variableRangeSchema is the JSON schema generated for min_value/max_value.
+type variableRangeSchema struct {
+	Type    string   `json:"type"`
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+}
+
+func validationSchemaAttribute() schema.Attribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Validation applied to the values of the variable. Only one of `enum_values`, `regex_pattern`, `json_schema` or a `min_value`/`max_value` range can be set. Default values and the variation values of features referencing the variable are checked against it during plan; JSON schemas are only checked to be valid JSON.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"enum_values": schema.ListAttribute{
+				MarkdownDescription: "Allowed values of the variable",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"regex_pattern": schema.StringAttribute{
+				MarkdownDescription: "Regular expression that String values must match",
+				Optional:            true,
+			},
+			"min_value": schema.Float64Attribute{
+				MarkdownDescription: "Minimum value of a Number variable",
+				Optional:            true,
+			},
+			"max_value": schema.Float64Attribute{
+				MarkdownDescription: "Maximum value of a Number variable",
+				Optional:            true,
+			},
+			"json_schema": schema.StringAttribute{
+				MarkdownDescription: "JSON schema that JSON values must conform to",
+				Optional:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the validation shown in the dashboard",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+type variableResourceDataValidationSchema struct {
+	EnumValues   types.List    `tfsdk:"enum_values"`
+	RegexPattern types.String  `tfsdk:"regex_pattern"`
+	MinValue     types.Float64 `tfsdk:"min_value"`
+	MaxValue     types.Float64 `tfsdk:"max_value"`
+	JSONSchema   types.String  `tfsdk:"json_schema"`
+	Description  types.String  `tfsdk:"description"`
+}
+
+// known reports whether all values of the validation schema are known.
+func (s variableResourceDataValidationSchema) known() bool {
+	if s.EnumValues.IsUnknown() || s.RegexPattern.IsUnknown() || s.MinValue.IsUnknown() || s.MaxValue.IsUnknown() || s.JSONSchema.IsUnknown() {
+		return false
+	}
+	for _, value := range s.EnumValues.Elements() {
+		if str, ok := value.(types.String); ok && str.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+func isSetString(v types.String) bool {
+	return !v.IsNull() && !v.IsUnknown()
+}
+
+func isSetFloat64(v types.Float64) bool {
+	return !v.IsNull() && !v.IsUnknown()
+}
+
+// validate checks that only one kind of validation is configured and that
+// it fits the variable type.
+func (s variableResourceDataValidationSchema) validate(ctx context.Context, variableType types.String, attrPath path.Path, diags *diag.Diagnostics) {
+	configured := 0
+	if !s.EnumValues.IsNull() {
+		configured++
+	}
+	if !s.RegexPattern.IsNull() {
+		configured++
+	}
+	if !s.JSONSchema.IsNull() {
+		configured++
+	}
+	if !s.MinValue.IsNull() || !s.MaxValue.IsNull() {
+		configured++
+	}
+	if configured > 1 {
+		diags.AddAttributeError(attrPath, "Invalid Validation Schema", "Only one of enum_values, regex_pattern, json_schema or a min_value/max_value range can be set.")
+		return
+	}
+
+	if isSetString(s.RegexPattern) {
+		if _, err := regexp.Compile(s.RegexPattern.ValueString()); err != nil {
+			diags.AddAttributeError(attrPath.AtName("regex_pattern"), "Invalid Validation Schema", fmt.Sprintf("Unable to compile regex_pattern: %s", err))
+		}
+		if isSetString(variableType) && variableType.ValueString() != "String" {
+			diags.AddAttributeError(attrPath.AtName("regex_pattern"), "Invalid Validation Schema", "regex_pattern can only be used with String variables.")
+		}
+	}
+	if isSetString(s.JSONSchema) && !json.Valid([]byte(s.JSONSchema.ValueString())) {
+		diags.AddAttributeError(attrPath.AtName("json_schema"), "Invalid Validation Schema", "json_schema must be a valid JSON document.")
+	}
+	if (!s.MinValue.IsNull() || !s.MaxValue.IsNull()) && isSetString(variableType) && variableType.ValueString() != "Number" {
+		diags.AddAttributeError(attrPath, "Invalid Validation Schema", "min_value and max_value can only be used with Number variables.")
+	}
+	if isSetFloat64(s.MinValue) && isSetFloat64(s.MaxValue) && s.MinValue.ValueFloat64() > s.MaxValue.ValueFloat64() {
+		diags.AddAttributeError(attrPath.AtName("min_value"), "Invalid Validation Schema", "min_value must not be greater than max_value.")
+	}
+	if !s.EnumValues.IsUnknown() && !s.EnumValues.IsNull() && isSetString(variableType) {
+		var values []types.String
+		diags.Append(s.EnumValues.ElementsAs(ctx, &values, false)...)
+		for i, value := range values {
+			if !isSetString(value) {
+				continue
+			}
+			if _, err := parseTypedValue(variableType.ValueString(), value.ValueString()); err != nil {
+				diags.AddAttributeError(attrPath.AtName("enum_values").AtListIndex(i), "Invalid Validation Schema", fmt.Sprintf("Unable to convert enum value %q to %s: %s", value.ValueString(), variableType.ValueString(), err))
+			}
+		}
+	}
+}
+
+func (s variableResourceDataValidationSchema) toSDK(ctx context.Context, variableType string, diags *diag.Diagnostics) *variableValidationSchema {
+	ret := &variableValidationSchema{
+		Description: s.Description.ValueString(),
+	}
+
+	switch {
+	case !s.EnumValues.IsNull():
+		var values []string
+		diags.Append(s.EnumValues.ElementsAs(ctx, &values, false)...)
+		ret.SchemaType = "enum"
+		for _, value := range values {
+			parsed, err := parseTypedValue(variableType, value)
+			if err != nil {
+				diags.AddError("Invalid Enum Value", fmt.Sprintf("Unable to convert enum value %q to %s: %s", value, variableType, err))
+				continue
+			}
+			ret.EnumValues = append(ret.EnumValues, parsed)
+		}
+	case !s.RegexPattern.IsNull():
+		ret.SchemaType = "regex"
+		ret.RegexPattern = s.RegexPattern.ValueString()
+	case !s.JSONSchema.IsNull():
+		ret.SchemaType = "jsonSchema"
+		ret.JSONSchema = s.JSONSchema.ValueString()
+	case !s.MinValue.IsNull() || !s.MaxValue.IsNull():
+		rangeSchema := variableRangeSchema{Type: "number"}
+		if !s.MinValue.IsNull() {
+			rangeSchema.Minimum = s.MinValue.ValueFloat64Pointer()
+		}
+		if !s.MaxValue.IsNull() {
+			rangeSchema.Maximum = s.MaxValue.ValueFloat64Pointer()
+		}
+		marshalled, err := json.Marshal(rangeSchema)
+		if err != nil {
+			diags.AddError("Invalid Validation Schema", err.Error())
+			return nil
+		}
+		ret.SchemaType = "jsonSchema"
+		ret.JSONSchema = string(marshalled)
+	default:
+		return nil
+	}
+	return ret
+}
+
+// validationSchemaToTF converts a validation schema returned by the API back
+// into its Terraform representation. The prior value decides whether a JSON
+// schema is shown as a min_value/max_value range or as json_schema.
+func validationSchemaToTF(s *variableValidationSchema, variableType string, prior *variableResourceDataValidationSchema) *variableResourceDataValidationSchema {
+	if s == nil || s.SchemaType == "" {
+		return nil
+	}
+
+	ret := &variableResourceDataValidationSchema{
+		EnumValues:   types.ListNull(types.StringType),
+		RegexPattern: types.StringNull(),
+		MinValue:     types.Float64Null(),
+		MaxValue:     types.Float64Null(),
+		JSONSchema:   types.StringNull(),
+		Description:  types.StringNull(),
+	}
+
+	if s.Description != "" {
+		ret.Description = types.StringValue(s.Description)
+	}
+
+	switch s.SchemaType {
+	case "enum":
+		values := []attr.Value{}
+		for _, value := range s.EnumValues {
+			formatted, err := formatTypedValue(variableType, value)
+			if err != nil {
+				formatted = fmt.Sprintf("%v", value)
+			}
+			values = append(values, types.StringValue(formatted))
+		}
+		ret.EnumValues = types.ListValueMust(types.StringType, values)
+	case "regex":
+		ret.RegexPattern = types.StringValue(s.RegexPattern)
+	case "jsonSchema":
+		if rangeSchema, ok := parseVariableRangeSchema(s.JSONSchema); ok && (prior == nil || prior.JSONSchema.IsNull()) {
+			if rangeSchema.Minimum != nil {
+				ret.MinValue = types.Float64Value(*rangeSchema.Minimum)
+			}
+			if rangeSchema.Maximum != nil {
+				ret.MaxValue = types.Float64Value(*rangeSchema.Maximum)
+			}
+		} else {
+			ret.JSONSchema = types.StringValue(s.JSONSchema)
+		}
+	}
+	return ret
+}
+
+// parseVariableRangeSchema reports whether a JSON schema only describes a
+// numeric range, i.e. whether it was generated from min_value/max_value.
+func parseVariableRangeSchema(schema string) (variableRangeSchema, bool) {
+	var rangeSchema variableRangeSchema
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(schema), &keys); err != nil {
+		return rangeSchema, false
+	}
+	for key := range keys {
+		switch key {
+		case "type", "minimum", "maximum":
+		default:
+			return rangeSchema, false
+		}
+	}
+	if err := json.Unmarshal([]byte(schema), &rangeSchema); err != nil || rangeSchema.Type != "number" {
+		return rangeSchema, false
+	}
+	return rangeSchema, true
+}
+
+// validateValue checks a typed variable value against the validation schema.
+// Arbitrary JSON schemas are not evaluated.
+func (s *variableValidationSchema) validateValue(variableType string, value interface{}) error {
+	if s == nil {
+		return nil
+	}
+
+	switch s.SchemaType {
+	case "enum":
+		formatted, err := formatTypedValue(variableType, value)
+		if err != nil {
+			return err
+		}
+		for _, allowed := range s.EnumValues {
+			if allowedFormatted, err := formatTypedValue(variableType, allowed); err == nil && allowedFormatted == formatted {
+				return nil
+			}
+		}
+		return fmt.Errorf("value %s is not one of the allowed enum values", formatted)
+	case "regex":
+		str, ok := value.(string)
+		if !ok {
+			return nil
+		}
+		re, err := regexp.Compile(s.RegexPattern)
+		if err != nil {
+			return fmt.Errorf("unable to compile regex pattern %q: %s", s.RegexPattern, err)
+		}
+		if !re.MatchString(str) {
+			return fmt.Errorf("value %q does not match the pattern %q", str, s.RegexPattern)
+		}
+	case "jsonSchema":
+		rangeSchema, ok := parseVariableRangeSchema(s.JSONSchema)
+		number, isNumber := value.(float64)
+		if !ok || !isNumber {
+			return nil
+		}
+		if rangeSchema.Minimum != nil && number < *rangeSchema.Minimum {
+			return fmt.Errorf("value %v is less than the minimum of %v", number, *rangeSchema.Minimum)
+		}
+		if rangeSchema.Maximum != nil && number > *rangeSchema.Maximum {
+			return fmt.Errorf("value %v is greater than the maximum of %v", number, *rangeSchema.Maximum)
+		}
+	}
+	return nil
+}
+
+// featureVariationPlan is the planned value of a devcycle_feature variation.
+// Unlike featureResourceDataVariation it tolerates unknown values.
+type featureVariationPlan struct {
+	Id        types.String `tfsdk:"id"`
+	Key       types.String `tfsdk:"key"`
+	Name      types.String `tfsdk:"name"`
+	Variables types.Map    `tfsdk:"variables"`
+}
+
+// validateVariationValues checks the planned variation values of a feature
+// against the validation schemas of the variables they reference. Variables
+// that don't exist yet, e.g. devcycle_variable resources created by the same
+// plan, have no schema to check against: they are warned about, unless they
+// are inline variables of the feature, which have no validation schema.
+func (p *devcycleProvider) validateVariationValues(ctx context.Context, projectID string, inline types.Set, variations types.Set, diags *diag.Diagnostics) {
+	inlineKeys := map[string]bool{}
+	for _, elem := range inline.Elements() {
+		if obj, ok := elem.(types.Object); ok {
+			if key, ok := obj.Attributes()["key"].(types.String); ok {
+				inlineKeys[key.ValueString()] = true
+			}
+		}
+	}
+
+	type lookupResult struct {
+		variable *variableWithValidation
+		missing  bool
+	}
+	variables := map[string]lookupResult{}
+	lookup := func(key string) lookupResult {
+		if result, ok := variables[key]; ok {
+			return result
+		}
+		var variable variableWithValidation
+		var result lookupResult
+		httpResponse, err := p.doMgmtJSONRequest(ctx, http.MethodGet, variablePath(projectID, key), nil, nil, &variable)
+		switch {
+		case httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound:
+			result.missing = true
+		case err == nil && httpResponse != nil && httpResponse.StatusCode == http.StatusOK:
+			result.variable = &variable
+		}
+		variables[key] = result
+		return result
+	}
+
+	for _, elem := range variations.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		var variation featureVariationPlan
+		diags.Append(obj.As(ctx, &variation, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() || variation.Variables.IsNull() || variation.Variables.IsUnknown() {
+			continue
+		}
+
+		for key, value := range variation.Variables.Elements() {
+			str, ok := value.(types.String)
+			if !ok || !isSetString(str) {
+				continue
+			}
+			if inlineKeys[key] {
+				continue
+			}
+			valuePath := path.Root("variations").AtSetValue(obj).AtName("variables").AtMapKey(key)
+			result := lookup(key)
+			if result.missing {
+				diags.AddAttributeWarning(valuePath, "Unvalidated Variable Value", fmt.Sprintf("Variable %q doesn't exist yet, so its value can't be checked against its validation schema until the variable is created.", key))
+				continue
+			}
+			variable := result.variable
+			if variable == nil || variable.ValidationSchema == nil {
+				continue
+			}
+			parsed, err := parseTypedValue(variable.Type_, str.ValueString())
+			if err != nil {
+				diags.AddAttributeError(valuePath, "Invalid Variable Value", fmt.Sprintf("Unable to convert value %q of variable %q to %s: %s", str.ValueString(), key, variable.Type_, err))
+				continue
+			}
+			if err := variable.ValidationSchema.validateValue(variable.Type_, parsed); err != nil {
+				diags.AddAttributeError(valuePath, "Invalid Variable Value", fmt.Sprintf("Value of variable %q doesn't satisfy its validation schema: %s", key, err))
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestVariableValidationSchemaValidateValue(t *testing.T) {
+	min, max := 1.0, 10.0
+	rangeSchema := `{"type":"number","minimum":1,"maximum":10}`
+
+	cases := []struct {
+		name         string
+		schema       *variableValidationSchema
+		variableType string
+		value        string
+		valid        bool
+	}{
+		{"no schema", nil, "String", "anything", true},
+		{"enum match", &variableValidationSchema{SchemaType: "enum", EnumValues: []interface{}{"a", "b"}}, "String", "b", true},
+		{"enum mismatch", &variableValidationSchema{SchemaType: "enum", EnumValues: []interface{}{"a", "b"}}, "String", "c", false},
+		{"number enum", &variableValidationSchema{SchemaType: "enum", EnumValues: []interface{}{1.0, 2.5}}, "Number", "2.50", true},
+		{"regex match", &variableValidationSchema{SchemaType: "regex", RegexPattern: "^v[0-9]+$"}, "String", "v12", true},
+		{"regex mismatch", &variableValidationSchema{SchemaType: "regex", RegexPattern: "^v[0-9]+$"}, "String", "12", false},
+		{"range inside", &variableValidationSchema{SchemaType: "jsonSchema", JSONSchema: rangeSchema}, "Number", "5", true},
+		{"range below", &variableValidationSchema{SchemaType: "jsonSchema", JSONSchema: rangeSchema}, "Number", "0.5", false},
+		{"range above", &variableValidationSchema{SchemaType: "jsonSchema", JSONSchema: rangeSchema}, "Number", "11", false},
+		{"arbitrary json schema", &variableValidationSchema{SchemaType: "jsonSchema", JSONSchema: `{"type":"object"}`}, "JSON", `{"a":1}`, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parsed, err := parseTypedValue(c.variableType, c.value)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %s", err)
+			}
+			err = c.schema.validateValue(c.variableType, parsed)
+			if c.valid && err != nil {
+				t.Errorf("expected value to be valid, got: %s", err)
+			}
+			if !c.valid && err == nil {
+				t.Errorf("expected value to be invalid")
+			}
+		})
+	}
+
+	parsedRange, ok := parseVariableRangeSchema(rangeSchema)
+	if !ok || *parsedRange.Minimum != min || *parsedRange.Maximum != max {
+		t.Errorf("expected range schema to round-trip, got %+v", parsedRange)
+	}
+	if _, ok := parseVariableRangeSchema(`{"type":"number","multipleOf":2}`); ok {
+		t.Errorf("expected schema with extra keywords not to be treated as a range")
+	}
+}
+
+func TestValidateVariationValues(t *testing.T) {
+	ctx := context.Background()
+	p := &devcycleProvider{MgmtHTTPClient: &http.Client{Transport: mgmtTestTransport(func(req *http.Request) (*http.Response, error) {
+		status, body := http.StatusNotFound, `{"message": "Not Found"}`
+		if req.URL.Path == "/v1/projects/project/variables/color" {
+			status, body = http.StatusOK, `{"key": "color", "type": "String", "validationSchema": {"schemaType": "enum", "enumValues": ["red", "blue"]}}`
+		}
+		return &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}}
+
+	var schemaResp fwresource.SchemaResponse
+	newFeatureResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	variationsType := schemaResp.Schema.Attributes["variations"].GetType().(types.SetType).ElemType
+	variations := func(values map[string]string) types.Set {
+		elems := map[string]attr.Value{}
+		for k, v := range values {
+			elems[k] = types.StringValue(v)
+		}
+		value, diags := types.SetValueFrom(ctx, variationsType, []featureVariationPlan{{
+			Id:        types.StringUnknown(),
+			Key:       types.StringValue("on"),
+			Name:      types.StringValue("On"),
+			Variables: types.MapValueMust(types.StringType, elems),
+		}})
+		if diags.HasError() {
+			t.Fatalf("unable to build variations: %v", diags)
+		}
+		return value
+	}
+
+	variablesType := schemaResp.Schema.Attributes["variables"].GetType().(types.SetType).ElemType
+	inline, d := types.SetValueFrom(ctx, variablesType, []featureResourceDataVariable{{
+		Key:         types.StringValue("inline"),
+		Type:        types.StringValue("String"),
+		Name:        types.StringNull(),
+		Description: types.StringNull(),
+		Id:          types.StringUnknown(),
+		FeatureKey:  types.StringUnknown(),
+		CreatedAt:   types.StringUnknown(),
+		UpdatedAt:   types.StringUnknown(),
+	}})
+	if d.HasError() {
+		t.Fatal(d)
+	}
+
+	var diags diag.Diagnostics
+	p.validateVariationValues(ctx, "project", inline, variations(map[string]string{"color": "red", "inline": "x"}), &diags)
+	if len(diags) != 0 {
+		t.Errorf("expected valid and inline variables to pass, got %v", diags)
+	}
+
+	p.validateVariationValues(ctx, "project", inline, variations(map[string]string{"color": "green"}), &diags)
+	if !diags.HasError() || diags[0].Summary() != "Invalid Variable Value" {
+		t.Errorf("expected an error about the value, got %v", diags)
+	}
+
+	// Variables created by the same plan have no schema to check against yet.
+	diags = nil
+	p.validateVariationValues(ctx, "project", types.SetNull(variablesType), variations(map[string]string{"new": "x"}), &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 || diags[0].Summary() != "Unvalidated Variable Value" {
+		t.Errorf("expected a warning about the new variable, got %v", diags)
+	}
+}
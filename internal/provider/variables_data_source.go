@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSourceWithConfigure = &variablesDataSource{}
+
+func newVariablesDataSource() datasource.DataSource {
+	return &variablesDataSource{}
+}
+
+func (d *variablesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variables"
+}
+
+func (d *variablesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Variables data source. Lists the variables of a project, optionally filtered.",
+
+		Attributes: listFilterAttributes(map[string]schema.Attribute{
+			"project_key": schema.StringAttribute{
+				MarkdownDescription: "Project key",
+				Required:            true,
+			},
+			"feature": schema.StringAttribute{
+				MarkdownDescription: "Only return variables of the feature with this key or ID",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only return variables of this type, one of `String`, `Boolean`, `Number` or `JSON`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(typedValueTypes...),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only return variables with this status, `active` or `archived`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringOneOf(variableStatusActive, variableStatusArchived),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Project key",
+				Computed:            true,
+			},
+			"variables": schema.ListNestedAttribute{
+				MarkdownDescription: "Variables matching the filters, sorted by key unless `sort_by` is set",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variable ID",
+						},
+						"key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variable key",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variable name",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variable description",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variable datatype",
+						},
+						"feature_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the feature the variable belongs to",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Variable status",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Created at timestamp",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Updated at timestamp",
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+type variablesDataSourceData struct {
+	listFilterData
+	Id         types.String                      `tfsdk:"id"`
+	ProjectKey types.String                      `tfsdk:"project_key"`
+	Feature    types.String                      `tfsdk:"feature"`
+	Type       types.String                      `tfsdk:"type"`
+	Status     types.String                      `tfsdk:"status"`
+	Variables  []variablesDataSourceDataVariable `tfsdk:"variables"`
+}
+
+type variablesDataSourceDataVariable struct {
+	Id          types.String `tfsdk:"id"`
+	Key         types.String `tfsdk:"key"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+	FeatureId   types.String `tfsdk:"feature_id"`
+	Status      types.String `tfsdk:"status"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+}
+
+type variablesDataSource struct {
+	dataSourceBase
+}
+
+func (d *variablesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data variablesDataSourceData
+	if !d.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := data.query()
+	if data.Feature.ValueString() != "" {
+		query.Set("feature", data.Feature.ValueString())
+	}
+	if data.Type.ValueString() != "" {
+		query.Set("type", data.Type.ValueString())
+	}
+	if data.Status.ValueString() != "" {
+		query.Set("status", data.Status.ValueString())
+	}
+	variables, httpResponse, err := listMgmtPages[variableWithValidation](ctx, d.provider, variablePath(data.ProjectKey.ValueString(), ""), query)
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+
+	var matching []variableWithValidation
+	for _, variable := range variables {
+		if variable.Status == "" {
+			variable.Status = variableStatusActive
+		}
+		// The type and status are filtered by the API too, they are checked
+		// again in case it ignores them. The feature can be a key, which
+		// variables don't reference, so it's left to the API.
+		if data.Type.ValueString() != "" && variable.Type_ != data.Type.ValueString() {
+			continue
+		}
+		if data.Status.ValueString() != "" && variable.Status != data.Status.ValueString() {
+			continue
+		}
+		matching = append(matching, variable)
+	}
+	matching = filterAndSortList(matching, data.listFilterData, func(variable variableWithValidation) listItemFields {
+		return listItemFields{Key: variable.Key, Name: variable.Name, CreatedAt: variable.CreatedAt, UpdatedAt: variable.UpdatedAt}
+	}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.ProjectKey
+	data.Variables = []variablesDataSourceDataVariable{}
+	for _, variable := range matching {
+		data.Variables = append(data.Variables, variablesDataSourceDataVariable{
+			Id:          types.StringValue(variable.Id),
+			Key:         types.StringValue(variable.Key),
+			Name:        types.StringValue(variable.Name),
+			Description: types.StringValue(variable.Description),
+			Type:        types.StringValue(variable.Type_),
+			FeatureId:   types.StringValue(variable.Feature),
+			Status:      types.StringValue(variable.Status),
+			CreatedAt:   listTimeToTF(variable.CreatedAt),
+			UpdatedAt:   listTimeToTF(variable.UpdatedAt),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
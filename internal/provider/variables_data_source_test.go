@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccVariablesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccVariablesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devcycle_variables.test", "id", "terraform-provider-testing"),
+					resource.TestCheckResourceAttrSet("data.devcycle_variables.test", "variables.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccVariablesDataSourceConfig = `
+data "devcycle_variables" "test" {
+  project_key = "terraform-provider-testing"
+}
+`
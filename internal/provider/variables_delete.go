@@ -10,24 +10,96 @@ import (
 
 	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func (p *provider) variablesControllerDelete(ctx context.Context, key, projectID string, diags *diag.Diagnostics) bool {
+func (p *devcycleProvider) variablesControllerDelete(ctx context.Context, key, projectID string, etag types.String, diags *diag.Diagnostics) bool {
+	found, etag, ret := p.detachVariable(ctx, key, projectID, etag, diags)
+	if ret || !found {
+		return ret
+	}
+
+	escapedProjectID := url.PathEscape(projectID)
+	escapedKey := url.PathEscape(key)
+	resp, err := p.doMgmtRequest(withIfMatch(ctx, etag), http.MethodDelete, fmt.Sprintf("/v1/projects/%s/variables/%s", escapedProjectID, escapedKey), nil, nil)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error deleting variable: %v", err))
+		return true
+	}
+	if resp.Body != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false
+	}
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		addResourceChangedError(diags)
+		return true
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: %s.\nRequest URL: %s", resp.Status, responseURL(resp)))
+		return true
+	}
+
+	return false
+}
+
+// variablesControllerArchive archives a variable instead of deleting it, so
+// that its history is kept for SDKs still referencing its key. Like delete,
+// the variable has to be detached from its feature first.
+func (p *devcycleProvider) variablesControllerArchive(ctx context.Context, key, projectID string, etag types.String, diags *diag.Diagnostics) bool {
+	found, etag, ret := p.detachVariable(ctx, key, projectID, etag, diags)
+	if ret || !found {
+		return ret
+	}
+
+	_, _, ret = p.setVariableStatus(withIfMatch(ctx, etag), key, projectID, variableStatusArchived, diags)
+	return ret
+}
+
+// setVariableStatus archives or unarchives a variable with the variable
+// status endpoint.
+func (p *devcycleProvider) setVariableStatus(ctx context.Context, key, projectID, status string, diags *diag.Diagnostics) (variableWithValidation, *http.Response, bool) {
+	var variable variableWithValidation
+	httpResp, err := p.doMgmtJSONRequest(ctx, http.MethodPatch, variablePath(projectID, key)+"/status", nil, map[string]string{
+		"status": status,
+	}, &variable)
+	if ret := handleDevCycleHTTP(err, httpResp, diags); ret {
+		return variable, httpResp, true
+	}
+	return variable, httpResp, false
+}
+
+// detachVariable removes a variable from the feature it is attached to, if
+// any. It reports whether the variable exists, and returns the etag the next
+// write to the variable must be sent with: the etag of the variable once
+// detached, as detaching changes it. The variable is left untouched when its
+// etag no longer matches etag.
+func (p *devcycleProvider) detachVariable(ctx context.Context, key, projectID string, etag types.String, diags *diag.Diagnostics) (bool, types.String, bool) {
 	variable, httpResp, err := p.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, key, projectID)
 	if httpResp != nil && httpResp.Body != nil {
 		_, _ = io.Copy(io.Discard, httpResp.Body)
 		_ = httpResp.Body.Close()
 	}
 	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
-		return false
+		return false, etag, false
 	}
 	if ret := handleDevCycleHTTP(err, httpResp, diags); ret {
-		return true
+		return true, etag, true
+	}
+	current := etagToTF(httpResp)
+	if isSetString(etag) && !current.IsNull() && current.ValueString() != etag.ValueString() {
+		addResourceChangedError(diags)
+		return true, etag, true
+	}
+	if current.IsNull() {
+		current = etag
 	}
 
 	if variable.Feature != "" {
 		if ret := p.detachVariableFromFeature(ctx, variable, projectID, diags); ret {
-			return true
+			return true, current, true
 		}
 
 		variable, httpResp, err = p.waitForDetachedVariable(ctx, key, projectID)
@@ -36,39 +108,20 @@ func (p *provider) variablesControllerDelete(ctx context.Context, key, projectID
 			_ = httpResp.Body.Close()
 		}
 		if ret := handleDevCycleHTTP(err, httpResp, diags); ret {
-			return true
+			return true, current, true
 		}
 		if variable.Feature != "" {
 			diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: variable %q is still associated with feature %q after detach", key, variable.Feature))
-			return true
+			return true, current, true
 		}
+		current = etagToTF(httpResp)
 	}
 
-	escapedProjectID := url.PathEscape(projectID)
-	escapedKey := url.PathEscape(key)
-	resp, err := p.doMgmtRequest(ctx, http.MethodDelete, fmt.Sprintf("/v1/projects/%s/variables/%s", escapedProjectID, escapedKey), nil, map[string]string{
-		"If-Match": "*",
-	})
-	if err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error deleting variable: %v", err))
-		return true
-	}
-	if resp.Body != nil {
-		_, _ = io.Copy(io.Discard, resp.Body)
-		_ = resp.Body.Close()
-	}
-	if resp.StatusCode == http.StatusNotFound {
-		return false
-	}
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: %s.\nRequest URL: %s", resp.Status, responseURL(resp)))
-		return true
-	}
-
-	return false
+	return true, current, false
 }
 
-func (p *provider) waitForDetachedVariable(ctx context.Context, key, projectID string) (devcyclem.Variable, *http.Response, error) {
+func (p *devcycleProvider) waitForDetachedVariable(ctx context.Context, key, projectID string) (devcyclem.Variable, *http.Response, error) {
+	ctx = withoutMgmtCache(ctx)
 	for attempt := 0; attempt < 5; attempt++ {
 		variable, httpResp, err := p.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, key, projectID)
 		if err != nil || httpResp == nil || httpResp.StatusCode == http.StatusNotFound || variable.Feature == "" {
@@ -87,17 +140,47 @@ func (p *provider) waitForDetachedVariable(ctx context.Context, key, projectID s
 	return p.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, key, projectID)
 }
 
-func (p *provider) detachVariableFromFeature(ctx context.Context, variable devcyclem.Variable, projectID string, diags *diag.Diagnostics) bool {
-	feature, httpResp, err := p.MgmtClient.FeaturesApi.FeaturesControllerFindOne(ctx, variable.Feature, projectID)
-	if httpResp != nil && httpResp.Body != nil {
-		_, _ = io.Copy(io.Discard, httpResp.Body)
-		_ = httpResp.Body.Close()
+func (p *devcycleProvider) detachVariableFromFeature(ctx context.Context, variable devcyclem.Variable, projectID string, diags *diag.Diagnostics) bool {
+	var feature featureWithSchemas
+	httpResp, err := p.doMgmtJSONRequest(ctx, http.MethodGet, featurePath(projectID, variable.Feature), nil, nil, &feature)
+	if ret := handleDevCycleHTTP(err, httpResp, diags); ret {
+		return true
+	}
+
+	removed := false
+	for _, existingVariable := range feature.Variables {
+		if existingVariable.Key == variable.Key {
+			removed = true
+			break
+		}
 	}
+	if !removed {
+		return false
+	}
+
+	update := featureUpdateFromFeature(feature, func(v devcyclem.Variable) bool {
+		return v.Key != variable.Key
+	}, nil)
+	for i := range update.Variations {
+		delete(update.Variations[i].Variables, variable.Key)
+	}
+
+	// The whole feature is sent back, so it must not have changed since it
+	// was read.
+	httpResp, err = p.doMgmtJSONRequest(withIfMatch(ctx, etagToTF(httpResp)), http.MethodPatch, featurePath(projectID, feature.Key), nil, update, nil)
 	if ret := handleDevCycleHTTP(err, httpResp, diags); ret {
 		return true
 	}
 
-	update := devcyclem.UpdateFeatureDto{
+	return false
+}
+
+// featureUpdateFromFeature builds a full feature update from an existing
+// feature, keeping only the variables and variations accepted by the given
+// filters. A nil filter keeps everything. The kept variables are sent back
+// with their validation schema.
+func featureUpdateFromFeature(feature featureWithSchemas, keepVariable func(devcyclem.Variable) bool, keepVariation func(devcyclem.Variation) bool) featureReplaceDto {
+	update := featureReplaceDto{
 		Name:        feature.Name,
 		Key:         feature.Key,
 		Description: feature.Description,
@@ -105,36 +188,31 @@ func (p *provider) detachVariableFromFeature(ctx context.Context, variable devcy
 		Tags:        feature.Tags,
 	}
 
-	removed := false
 	for _, existingVariable := range feature.Variables {
-		if existingVariable.Key == variable.Key {
-			removed = true
+		if keepVariable != nil && !keepVariable(existingVariable) {
 			continue
 		}
-
-		update.Variables = append(update.Variables, devcyclem.CreateVariableDto{
-			Name:         existingVariable.Name,
-			Description:  existingVariable.Description,
-			Key:          existingVariable.Key,
-			Feature:      feature.Key,
-			Type_:        existingVariable.Type_,
-			DefaultValue: existingVariable.DefaultValue,
+		update.Variables = append(update.Variables, featureVariableDto{
+			CreateVariableDto: devcyclem.CreateVariableDto{
+				Name:         existingVariable.Name,
+				Description:  existingVariable.Description,
+				Key:          existingVariable.Key,
+				Feature:      feature.Key,
+				Type_:        existingVariable.Type_,
+				DefaultValue: existingVariable.DefaultValue,
+			},
+			ValidationSchema: feature.schemas[existingVariable.Key],
 		})
 	}
 
-	if !removed {
-		return false
-	}
-
 	for _, variation := range feature.Variations {
+		if keepVariation != nil && !keepVariation(variation) {
+			continue
+		}
 		nextVariables := make(map[string]interface{}, len(variation.Variables))
 		for variationKey, variationValue := range variation.Variables {
-			if variationKey == variable.Key {
-				continue
-			}
 			nextVariables[variationKey] = variationValue
 		}
-
 		update.Variations = append(update.Variations, devcyclem.FeatureVariationDto{
 			Key:       variation.Key,
 			Name:      variation.Name,
@@ -142,19 +220,37 @@ func (p *provider) detachVariableFromFeature(ctx context.Context, variable devcy
 		})
 	}
 
-	_, httpResp, err = p.MgmtClient.FeaturesApi.FeaturesControllerUpdate(ctx, update, feature.Key, projectID)
-	if httpResp != nil && httpResp.Body != nil {
-		_, _ = io.Copy(io.Discard, httpResp.Body)
-		_ = httpResp.Body.Close()
-	}
+	return update
+}
+
+// setFeatureStatus changes the status of a feature with the feature status
+// endpoint. Completing a feature serves the static variation everywhere.
+func (p *devcycleProvider) setFeatureStatus(ctx context.Context, key, projectID, status, staticVariation string, diags *diag.Diagnostics) (featureWithStatus, *http.Response, bool) {
+	var feature featureWithStatus
+	httpResp, err := p.doMgmtJSONRequest(ctx, http.MethodPatch, featurePath(projectID, key)+"/status", nil, featureStatusDto{
+		Status:          status,
+		StaticVariation: staticVariation,
+	}, &feature)
 	if ret := handleDevCycleHTTP(err, httpResp, diags); ret {
-		return true
+		return feature, httpResp, true
 	}
+	return feature, httpResp, false
+}
 
-	return false
+type featureStatusDto struct {
+	Status          string `json:"status"`
+	StaticVariation string `json:"staticVariation,omitempty"`
+}
+
+func featurePath(project, key string) string {
+	path := fmt.Sprintf("/v1/projects/%s/features", url.PathEscape(project))
+	if key != "" {
+		path += "/" + url.PathEscape(key)
+	}
+	return path
 }
 
-func (p *provider) featureControllerDelete(ctx context.Context, key, projectID string, diags *diag.Diagnostics) bool {
+func (p *devcycleProvider) featureControllerDelete(ctx context.Context, key, projectID string, diags *diag.Diagnostics) bool {
 	escapedProjectID := url.PathEscape(projectID)
 	escapedKey := url.PathEscape(key)
 	resp, err := p.doMgmtRequest(ctx, http.MethodDelete, fmt.Sprintf("/v1/projects/%s/features/%s", escapedProjectID, escapedKey), url.Values{
@@ -171,6 +267,10 @@ func (p *provider) featureControllerDelete(ctx context.Context, key, projectID s
 	if resp.StatusCode == http.StatusNotFound {
 		return false
 	}
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		addResourceChangedError(diags)
+		return true
+	}
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
 		diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: %s.\nRequest URL: %s", resp.Status, responseURL(resp)))
 		return true
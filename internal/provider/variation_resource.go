@@ -0,0 +1,357 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.ResourceWithConfigure = &variationResource{}
+var _ resource.ResourceWithImportState = &variationResource{}
+
+func newVariationResource() resource.Resource {
+	return &variationResource{}
+}
+
+func (r *variationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_variation"
+}
+
+func (r *variationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Variation resource. Manages a single variation of a feature. The feature must leave `variations` unset when its variations are managed with this resource. A feature keeps at least one variation, so its last variation can only be deleted along with the feature, after removing it from the state.",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "Project id or key that this feature and variation is attached to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"feature_id": schema.StringAttribute{
+				MarkdownDescription: "Feature id or key that this variation is attached to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "Variation key",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Variation name",
+				Required:            true,
+			},
+			"variables": schema.MapAttribute{
+				MarkdownDescription: "Variable values served by this variation, keyed by variable key. Values are converted to the type of the matching feature variable; JSON values must be encoded with `jsonencode`.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Variation ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+type variationResourceData struct {
+	Id        types.String      `tfsdk:"id"`
+	ProjectId types.String      `tfsdk:"project_id"`
+	FeatureId types.String      `tfsdk:"feature_id"`
+	Key       types.String      `tfsdk:"key"`
+	Name      types.String      `tfsdk:"name"`
+	Variables map[string]string `tfsdk:"variables"`
+}
+
+// variationVariablesToSDK converts the string encoded variable values of a
+// variation into the typed values expected by the management API, using the
+// variable types defined on the feature. Errors are reported on the path
+// returned by valuePath for the key of the value.
+func variationVariablesToSDK(valuePath func(key string) path.Path, values map[string]string, variables []devcyclem.Variable, diags *diag.Diagnostics) map[string]interface{} {
+	variableTypes := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		variableTypes[variable.Key] = variable.Type_
+	}
+
+	ret := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		variableType, ok := variableTypes[key]
+		if !ok {
+			diags.AddAttributeError(
+				valuePath(key),
+				"Unknown Variable",
+				fmt.Sprintf("Variable %q is not defined on the feature.", key),
+			)
+			continue
+		}
+		parsed, err := parseTypedValue(variableType, value)
+		if err != nil {
+			diags.AddAttributeError(
+				valuePath(key),
+				"Invalid Variable Value",
+				fmt.Sprintf("Unable to convert value %q of variable %q to %s: %s", value, key, variableType, err),
+			)
+			continue
+		}
+		ret[key] = parsed
+	}
+	return ret
+}
+
+// variationVariablesToTF is the inverse of variationVariablesToSDK.
+func variationVariablesToTF(values map[string]interface{}, variables []devcyclem.Variable) map[string]string {
+	variableTypes := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		variableTypes[variable.Key] = variable.Type_
+	}
+
+	ret := make(map[string]string, len(values))
+	for key, value := range values {
+		formatted, err := formatTypedValue(variableTypes[key], value)
+		if err != nil {
+			formatted = fmt.Sprintf("%v", value)
+		}
+		ret[key] = formatted
+	}
+	return ret
+}
+
+func findVariation(feature devcyclem.Feature, key string) (devcyclem.Variation, bool) {
+	for _, variation := range feature.Variations {
+		if variation.Key == key {
+			return variation, true
+		}
+	}
+	return devcyclem.Variation{}, false
+}
+
+func variationsPath(project, feature, key string) string {
+	path := fmt.Sprintf("/v1/projects/%s/features/%s/variations", url.PathEscape(project), url.PathEscape(feature))
+	if key != "" {
+		path += "/" + url.PathEscape(key)
+	}
+	return path
+}
+
+type variationResource struct {
+	resourceBase
+}
+
+// getFeature fetches the feature owning the variation. A missing feature is
+// not reported as an error so that callers can decide how to handle it.
+func (r *variationResource) getFeature(ctx context.Context, data variationResourceData, diags *diag.Diagnostics) (featureWithSchemas, *http.Response, bool) {
+	var feature featureWithSchemas
+	httpResponse, err := r.provider.doMgmtJSONRequest(ctx, http.MethodGet, featurePath(data.ProjectId.ValueString(), data.FeatureId.ValueString()), nil, nil, &feature)
+	if httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound {
+		return feature, httpResponse, false
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, diags); ret {
+		return feature, httpResponse, true
+	}
+	return feature, httpResponse, false
+}
+
+// write creates or updates the variation, then reads back the owning feature
+// to populate the state.
+func (r *variationResource) write(ctx context.Context, method string, data *variationResourceData, diags *diag.Diagnostics) {
+	feature, httpResponse, ret := r.getFeature(ctx, *data, diags)
+	if ret {
+		return
+	}
+	if httpResponse.StatusCode == http.StatusNotFound {
+		diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: feature %q was not found in project %q", data.FeatureId.ValueString(), data.ProjectId.ValueString()))
+		return
+	}
+
+	body := devcyclem.FeatureVariationDto{
+		Key:       data.Key.ValueString(),
+		Name:      data.Name.ValueString(),
+		Variables: variationVariablesToSDK(path.Root("variables").AtMapKey, data.Variables, feature.Variables, diags),
+	}
+	if diags.HasError() {
+		return
+	}
+
+	key := ""
+	if method == http.MethodPatch {
+		key = data.Key.ValueString()
+	}
+	// Variations are part of their feature, the write is checked against the
+	// etag of the feature the variation values were typed with.
+	httpResponse, err := r.provider.doMgmtJSONRequest(withIfMatch(ctx, etagToTF(httpResponse)), method, variationsPath(data.ProjectId.ValueString(), feature.Key, key), nil, body, nil)
+	if ret := handleDevCycleHTTP(err, httpResponse, diags); ret {
+		return
+	}
+
+	feature, _, ret = r.getFeature(ctx, *data, diags)
+	if ret {
+		return
+	}
+	variation, ok := findVariation(feature.Feature, data.Key.ValueString())
+	if !ok {
+		diags.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: variation %q was not found on feature %q after write", data.Key.ValueString(), feature.Key))
+		return
+	}
+	data.Id = types.StringValue(variation.Id)
+	data.Name = types.StringValue(variation.Name)
+	data.Variables = variationVariablesToTF(variation.Variables, feature.Variables)
+}
+
+func (r *variationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data variationResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.write(ctx, http.MethodPost, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *variationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data variationResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	feature, httpResponse, ret := r.getFeature(ctx, data, &resp.Diagnostics)
+	if ret {
+		return
+	}
+	variation, ok := findVariation(feature.Feature, data.Key.ValueString())
+	if httpResponse.StatusCode == http.StatusNotFound || !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(variation.Id)
+	data.Key = types.StringValue(variation.Key)
+	data.Name = types.StringValue(variation.Name)
+	data.Variables = variationVariablesToTF(variation.Variables, feature.Variables)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *variationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data variationResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.write(ctx, http.MethodPatch, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *variationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data variationResourceData
+	if !r.requireMgmtClient(&resp.Diagnostics) {
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	feature, httpResponse, ret := r.getFeature(ctx, data, &resp.Diagnostics)
+	if ret {
+		return
+	}
+	if _, ok := findVariation(feature.Feature, data.Key.ValueString()); httpResponse.StatusCode == http.StatusNotFound || !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The management API has no variation delete operation, so the feature is
+	// updated without the variation instead.
+	update := featureUpdateFromFeature(feature, nil, func(v devcyclem.Variation) bool {
+		return v.Key != data.Key.ValueString()
+	})
+	if len(update.Variations) == 0 {
+		// A feature always needs at least one variation, the last one is
+		// only removed along with the feature itself.
+		resp.Diagnostics.AddError(
+			"Unable to Delete Last Variation",
+			fmt.Sprintf("Variation %q is the last variation of feature %q, which must keep at least one variation. "+
+				"Add another variation to the feature before removing this one, or delete the feature, which deletes its variations: "+
+				"to destroy both, first remove this variation from the state with terraform state rm.", data.Key.ValueString(), feature.Key),
+		)
+		return
+	}
+
+	// The whole feature is sent back, so it must not have changed since it
+	// was read.
+	httpResponse, err := r.provider.doMgmtJSONRequest(withIfMatch(ctx, etagToTF(httpResponse)), http.MethodPatch, featurePath(data.ProjectId.ValueString(), feature.Key), nil, update, nil)
+	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *variationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := splitImportID(req.ID, 3)
+	if parts == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: <project>/<feature>/<key>. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("feature_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), parts[2])...)
+}
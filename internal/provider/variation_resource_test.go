@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccVariationResource(t *testing.T) {
+	testAccPreCheck(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 nil,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccVariationResourceConfig("true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_variation.test", "key", "test-variation-key"+randString+"2"),
+					resource.TestCheckResourceAttr("devcycle_variation.test", "variables.test-variable-key"+randString, "true"),
+				),
+			},
+			{
+				Config: testAccVariationResourceConfig("false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_variation.test", "variables.test-variable-key"+randString, "false"),
+				),
+			},
+			{
+				Config:  testAccVariationResourceConfig("false"),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestVariationResourceDeleteLastVariation(t *testing.T) {
+	var requests []string
+	transport := mgmtTestTransport(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		body := `{"_id": "feature-id", "key": "feature", "variables": [], "variations": [{"_id": "variation-id", "key": "on", "name": "On"}]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     http.StatusText(http.StatusOK),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	r := &variationResource{resourceBase{providerBase{provider: &devcycleProvider{MgmtHTTPClient: &http.Client{Transport: transport}}}}}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: remoteValidationObject(t, r, map[string]string{
+		"id":         "variation-id",
+		"project_id": "project",
+		"feature_id": "feature",
+		"key":        "on",
+		"name":       "On",
+	})}
+	resp := &fwresource.DeleteResponse{State: state}
+	r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Unable to Delete Last Variation" {
+		t.Errorf("expected the deletion of the last variation to fail, got %v", resp.Diagnostics)
+	}
+	if resp.State.Raw.IsNull() {
+		t.Error("expected the variation to be kept in the state")
+	}
+	if len(requests) != 1 || requests[0] != "GET /v1/projects/project/features/feature" {
+		t.Errorf("expected the feature to only be read, got %v", requests)
+	}
+}
+
+func testAccVariationResourceConfig(value string) string {
+	return `
+resource "devcycle_feature" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  name = "TerraformAccTest` + randString + `"
+  key = "terraform-acceptance-testing` + randString + `"
+  description = "Terraform acceptance testing"
+  type = "experiment"
+  tags = ["acceptance-testing"]
+  variables = [
+	{
+	  name = "test-variable-name` + randString + `"
+	  description = "description"
+      key = "test-variable-key` + randString + `"
+      type = "Boolean"
+	}
+  ]
+}
+
+resource "devcycle_variation" "test" {
+  project_id = devcycle_feature.test.project_id
+  feature_id = devcycle_feature.test.key
+  key = "test-variation-key` + randString + `2"
+  name = "test-variation-name` + randString + `2"
+  variables = {
+	"test-variable-key` + randString + `" = "` + value + `"
+  }
+}
+`
+}
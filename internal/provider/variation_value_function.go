@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ function.Function = &encodeVariationValueFunction{}
+var _ function.Function = &decodeVariationValueFunction{}
+
+func newEncodeVariationValueFunction() function.Function {
+	return &encodeVariationValueFunction{}
+}
+
+func newDecodeVariationValueFunction() function.Function {
+	return &decodeVariationValueFunction{}
+}
+
+type encodeVariationValueFunction struct{}
+
+func (f *encodeVariationValueFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "encode_variation_value"
+}
+
+func (f *encodeVariationValueFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Encode a variable value for a variation",
+		MarkdownDescription: "Encodes a value into the string representation used by the `variables` of variations and the `default_value` of variables. Strings are treated as already encoded and are validated and normalized, e.g. `\"1.50\"` encodes to `\"1.5\"` for a `Number`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "type",
+				MarkdownDescription: "Variable type, one of `String`, `Number`, `Boolean` or `JSON`",
+			},
+			function.DynamicParameter{
+				Name:                "value",
+				MarkdownDescription: "Value to encode",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *encodeVariationValueFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var valueType string
+	var value types.Dynamic
+
+	resp.Error = req.Arguments.Get(ctx, &valueType, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	if !isTypedValueType(valueType) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unknown type %q, expected one of: %s", valueType, strings.Join(typedValueTypes, ", ")))
+		return
+	}
+
+	tfValue, err := value.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Unable to read value: %s", err))
+		return
+	}
+	raw, err := tftypesValueToInterface(tfValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Unable to read value: %s", err))
+		return
+	}
+
+	encoded, err := encodeTypedValue(valueType, raw)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Unable to encode value as %s: %s", valueType, err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, encoded)
+}
+
+type decodeVariationValueFunction struct{}
+
+func (f *decodeVariationValueFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "decode_variation_value"
+}
+
+func (f *decodeVariationValueFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Decode a variable value of a variation",
+		MarkdownDescription: "Decodes the string representation used by the `variables` of variations and the `default_value` of variables into a value of the matching Terraform type: a string, number, bool, or for `JSON` the decoded document.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "type",
+				MarkdownDescription: "Variable type, one of `String`, `Number`, `Boolean` or `JSON`",
+			},
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "Encoded value",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *decodeVariationValueFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var valueType, value string
+
+	resp.Error = req.Arguments.Get(ctx, &valueType, &value)
+	if resp.Error != nil {
+		return
+	}
+
+	if !isTypedValueType(valueType) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unknown type %q, expected one of: %s", valueType, strings.Join(typedValueTypes, ", ")))
+		return
+	}
+
+	parsed, err := parseTypedValue(valueType, value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Unable to decode value %q as %s: %s", value, valueType, err))
+		return
+	}
+
+	if parsed == nil {
+		resp.Error = resp.Result.Set(ctx, types.DynamicNull())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(interfaceToAttrValue(parsed)))
+}
+
+func isTypedValueType(valueType string) bool {
+	for _, t := range typedValueTypes {
+		if t == valueType {
+			return true
+		}
+	}
+	return false
+}
+
+// tftypesValueToInterface converts a Terraform value into the equivalent
+// value decoded from JSON, e.g. float64 for numbers and map[string]interface{}
+// for objects.
+func tftypesValueToInterface(value tftypes.Value) (interface{}, error) {
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("value is unknown")
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch value.Type().(type) {
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var elems []tftypes.Value
+		if err := value.As(&elems); err != nil {
+			return nil, err
+		}
+		ret := make([]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			converted, err := tftypesValueToInterface(elem)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, converted)
+		}
+		return ret, nil
+	case tftypes.Map, tftypes.Object:
+		var attrs map[string]tftypes.Value
+		if err := value.As(&attrs); err != nil {
+			return nil, err
+		}
+		ret := make(map[string]interface{}, len(attrs))
+		for name, attr := range attrs {
+			converted, err := tftypesValueToInterface(attr)
+			if err != nil {
+				return nil, err
+			}
+			ret[name] = converted
+		}
+		return ret, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case value.Type().Is(tftypes.Number):
+		var n big.Float
+		if err := value.As(&n); err != nil {
+			return nil, err
+		}
+		f, _ := n.Float64()
+		return f, nil
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	}
+	return nil, fmt.Errorf("unsupported type %s", value.Type())
+}
+
+// interfaceToAttrValue is the inverse of tftypesValueToInterface. JSON arrays
+// become tuples and JSON objects become objects, matching jsondecode. Nested
+// nulls are typed as strings since a value of an object can't be dynamic.
+func interfaceToAttrValue(value interface{}) attr.Value {
+	switch v := value.(type) {
+	case string:
+		return types.StringValue(v)
+	case float64:
+		return types.NumberValue(big.NewFloat(v))
+	case bool:
+		return types.BoolValue(v)
+	case []interface{}:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+		for _, elem := range v {
+			converted := interfaceToAttrValue(elem)
+			elemTypes = append(elemTypes, converted.Type(context.Background()))
+			elems = append(elems, converted)
+		}
+		return types.TupleValueMust(elemTypes, elems)
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for name, elem := range v {
+			converted := interfaceToAttrValue(elem)
+			attrTypes[name] = converted.Type(context.Background())
+			attrs[name] = converted
+		}
+		return types.ObjectValueMust(attrTypes, attrs)
+	}
+	return types.StringNull()
+}
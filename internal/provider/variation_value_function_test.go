@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEncodeVariationValueFunction(t *testing.T) {
+	object := types.ObjectValueMust(
+		map[string]attr.Type{"enabled": types.BoolType, "limit": types.NumberType},
+		map[string]attr.Value{"enabled": types.BoolValue(true), "limit": types.NumberValue(big.NewFloat(10))},
+	)
+
+	cases := []struct {
+		name      string
+		valueType string
+		value     attr.Value
+		expected  string
+		valid     bool
+	}{
+		{"string", "String", types.StringValue("hello"), "hello", true},
+		{"number", "Number", types.NumberValue(big.NewFloat(1.5)), "1.5", true},
+		{"encoded number", "Number", types.StringValue("1.50"), "1.5", true},
+		{"bool", "Boolean", types.BoolValue(true), "true", true},
+		{"encoded bool", "Boolean", types.StringValue("false"), "false", true},
+		{"object", "JSON", object, `{"enabled":true,"limit":10}`, true},
+		{"encoded json", "JSON", types.StringValue(`{ "a": [1, 2] }`), `{"a":[1,2]}`, true},
+		{"invalid number", "Number", types.StringValue("abc"), "", false},
+		{"bool for number", "Number", types.BoolValue(true), "", false},
+		{"number for string", "String", types.NumberValue(big.NewFloat(1)), "", false},
+		{"invalid json", "JSON", types.StringValue("{"), "", false},
+		{"unknown type", "Text", types.StringValue("hello"), "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+			newEncodeVariationValueFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(c.valueType), types.DynamicValue(c.value)}),
+			}, &resp)
+			if !c.valid {
+				if resp.Error == nil {
+					t.Errorf("expected %s to be rejected as %s", c.value, c.valueType)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			if !resp.Result.Value().Equal(types.StringValue(c.expected)) {
+				t.Errorf("expected %q, got %s", c.expected, resp.Result.Value())
+			}
+		})
+	}
+}
+
+func TestDecodeVariationValueFunction(t *testing.T) {
+	cases := []struct {
+		name      string
+		valueType string
+		value     string
+		expected  attr.Value
+		valid     bool
+	}{
+		{"string", "String", "hello", types.StringValue("hello"), true},
+		{"number", "Number", "1.5", types.NumberValue(big.NewFloat(1.5)), true},
+		{"bool", "Boolean", "true", types.BoolValue(true), true},
+		{"json", "JSON", `{"tags":["a"],"limit":10}`, types.ObjectValueMust(
+			map[string]attr.Type{"tags": types.TupleType{ElemTypes: []attr.Type{types.StringType}}, "limit": types.NumberType},
+			map[string]attr.Value{
+				"tags":  types.TupleValueMust([]attr.Type{types.StringType}, []attr.Value{types.StringValue("a")}),
+				"limit": types.NumberValue(big.NewFloat(10)),
+			},
+		), true},
+		{"invalid bool", "Boolean", "yes", nil, false},
+		{"unknown type", "Text", "hello", nil, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp := function.RunResponse{Result: function.NewResultData(types.DynamicUnknown())}
+			newDecodeVariationValueFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(c.valueType), types.StringValue(c.value)}),
+			}, &resp)
+			if !c.valid {
+				if resp.Error == nil {
+					t.Errorf("expected %q to be rejected as %s", c.value, c.valueType)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			if !resp.Result.Value().Equal(types.DynamicValue(c.expected)) {
+				t.Errorf("expected %s, got %s", c.expected, resp.Result.Value())
+			}
+		})
+	}
+}
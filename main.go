@@ -5,7 +5,7 @@ import (
 	"log"
 
 	"github.com/devcyclehq/terraform-provider-devcycle/internal/provider"
-	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -28,11 +28,17 @@ var (
 )
 
 func main() {
-	opts := tfsdk.ServeOpts{
-		Name: "registry.terraform.io/DevCycleHQ/devcycle",
+	opts := providerserver.ServeOpts{
+		Address: "registry.terraform.io/DevCycleHQ/devcycle",
 	}
 
-	err := tfsdk.Serve(context.Background(), provider.New(version), opts)
+	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	// Flush the evaluation events of the server SDK clients once Terraform
+	// stops the provider.
+	if closeErr := provider.CloseServerClients(); closeErr != nil {
+		log.Printf("[WARN] Unable to close the DevCycle server SDK clients: %s", closeErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())